@@ -24,7 +24,8 @@ async fn main() -> Result<(), Error> {
         .append_file(file, file_extra_bytes)
         .sign(&env::var("OPERATOR_SECRET")?.parse()?) // sign as the owner of the file to approve the change
         .execute_async()
-        .await?;
+        .await?
+        .transaction_id;
 
     println!("appending to file; transaction = {}", id);
 
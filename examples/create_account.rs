@@ -28,7 +28,8 @@ async fn main() -> Result<(), Error> {
         .initial_balance(5_000_000)
         .memo("[hedera-sdk-rust][example] create_account")
         .execute_async()
-        .await?;
+        .await?
+        .transaction_id;
 
     println!("created account; transaction = {}", id);
 
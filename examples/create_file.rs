@@ -33,7 +33,8 @@ async fn main() -> Result<(), Error> {
         .memo("[hedera-sdk-rust][example] create_file")
         .sign(&env::var("OPERATOR_SECRET")?.parse()?) // sign as the owner of the file
         .execute_async()
-        .await?;
+        .await?
+        .transaction_id;
 
     println!("creating file; transaction = {}", id);
 
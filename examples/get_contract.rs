@@ -0,0 +1,24 @@
+use failure::Error;
+use hedera::Client;
+use std::env;
+
+fn main() -> Result<(), Error> {
+    pretty_env_logger::try_init()?;
+
+    let operator = env::var("OPERATOR")?.parse()?;
+    let client = Client::builder("testnet.hedera.com:50131")
+        .node("0:0:3".parse()?)
+        .operator(operator, || env::var("OPERATOR_SECRET"))
+        .build()?;
+
+    let contract = env::var("CONTRACT")?.parse()?;
+
+    let info = client.contract(contract).info().get()?;
+    println!("info = {:#?}", info);
+
+    // The bytecode returned is the runtime bytecode, i.e. what the constructor left behind
+    let bytecode = client.contract(contract).bytecode().get()?;
+    println!("bytecode = {}", hex::encode(&bytecode));
+
+    Ok(())
+}
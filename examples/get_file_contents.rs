@@ -0,0 +1,25 @@
+use failure::Error;
+use hedera::Client;
+use std::env;
+
+#[tokio::main]
+async fn main() -> Result<(), Error> {
+    pretty_env_logger::try_init()?;
+
+    // Operator is the account that pays for the query
+    let operator = env::var("OPERATOR")?.parse()?;
+    let client = Client::builder("testnet.hedera.com:50131")
+        .node("0:0:3".parse()?)
+        .operator(operator, || env::var("OPERATOR_SECRET"))
+        .build()?;
+
+    // Read the raw bytes stored in a file
+    // 0:0:112 holds the current exchange rates
+    let file = "0:0:112".parse()?;
+    let contents = client.file(file).contents().get_async().await?;
+
+    println!("file {} is {} bytes", file, contents.len());
+    println!("contents = {}", hex::encode(&contents));
+
+    Ok(())
+}
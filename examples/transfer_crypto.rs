@@ -26,7 +26,8 @@ async fn main() -> Result<(), Error> {
         .sign(&env::var("OPERATOR_SECRET")?.parse()?)
         .sign(&env::var("OPERATOR_SECRET")?.parse()?)
         .execute_async()
-        .await?;
+        .await?
+        .transaction_id;
 
     println!("created transfer; transaction = {}", id);
 
@@ -27,7 +27,8 @@ async fn main() -> Result<(), Error> {
         .expires_in(Duration::from_secs(2_592_000))
         .sign(&env::var("OPERATOR_SECRET")?.parse()?) // sign as the owner of the account to approve the change
         .execute_async()
-        .await?;
+        .await?
+        .transaction_id;
 
     println!("updating account; transaction = {}", id);
 
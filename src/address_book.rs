@@ -0,0 +1,136 @@
+use crate::{
+    proto::{self, ToProto},
+    AccountId,
+};
+use failure::Error;
+use std::net::Ipv4Addr;
+use try_from::TryFrom;
+
+/// The address of a single node in the network.
+#[derive(Debug, Clone, PartialEq)]
+pub struct NodeAddress {
+    pub ip_address: String,
+    pub port: i32,
+    pub memo: String,
+    pub rsa_public_key: String,
+    pub node_id: i64,
+    pub node_account_id: Option<AccountId>,
+    /// The hex encoded SHA-384 hash of the TLS certificate of the node.
+    pub cert_hash: Vec<u8>,
+    pub service_endpoints: Vec<ServiceEndpoint>,
+    pub description: String,
+}
+
+impl NodeAddress {
+    /// The account of the node. Older address books store it as the memo of the node address.
+    pub fn account_id(&self) -> Result<AccountId, Error> {
+        match self.node_account_id {
+            Some(id) => Ok(id),
+            None => self.memo.parse(),
+        }
+    }
+}
+
+impl From<proto::BasicTypes::NodeAddress> for NodeAddress {
+    fn from(mut address: proto::BasicTypes::NodeAddress) -> Self {
+        Self {
+            ip_address: String::from_utf8_lossy(&address.take_ipAddress()).into_owned(),
+            port: address.get_portno(),
+            memo: String::from_utf8_lossy(&address.take_memo()).into_owned(),
+            rsa_public_key: address.take_RSA_PubKey(),
+            node_id: address.get_nodeId(),
+            node_account_id: if address.has_nodeAccountId() {
+                Some(address.take_nodeAccountId().into())
+            } else {
+                None
+            },
+            cert_hash: address.take_nodeCertHash(),
+            service_endpoints: address
+                .take_serviceEndpoint()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            description: address.take_description(),
+        }
+    }
+}
+
+/// A network endpoint of a node, reachable by either an IPv4 address or a domain name.
+#[derive(Debug, Clone, PartialEq)]
+pub struct ServiceEndpoint {
+    pub ip_address: Option<Ipv4Addr>,
+    pub domain_name: String,
+    pub port: i32,
+}
+
+impl ServiceEndpoint {
+    pub fn from_ip(ip_address: Ipv4Addr, port: i32) -> Self {
+        Self {
+            ip_address: Some(ip_address),
+            domain_name: String::new(),
+            port,
+        }
+    }
+
+    pub fn from_domain_name(domain_name: impl Into<String>, port: i32) -> Self {
+        Self {
+            ip_address: None,
+            domain_name: domain_name.into(),
+            port,
+        }
+    }
+}
+
+impl From<proto::BasicTypes::ServiceEndpoint> for ServiceEndpoint {
+    fn from(mut endpoint: proto::BasicTypes::ServiceEndpoint) -> Self {
+        let ip_address = match endpoint.get_ipAddressV4() {
+            &[a, b, c, d] => Some(Ipv4Addr::new(a, b, c, d)),
+            _ => None,
+        };
+
+        Self {
+            ip_address,
+            domain_name: endpoint.take_domain_name(),
+            port: endpoint.get_port(),
+        }
+    }
+}
+
+impl ToProto<proto::BasicTypes::ServiceEndpoint> for ServiceEndpoint {
+    fn to_proto(&self) -> Result<proto::BasicTypes::ServiceEndpoint, Error> {
+        let mut endpoint = proto::BasicTypes::ServiceEndpoint::new();
+
+        if let Some(ip_address) = self.ip_address {
+            endpoint.set_ipAddressV4(ip_address.octets().to_vec());
+        }
+
+        endpoint.set_domain_name(self.domain_name.clone());
+        endpoint.set_port(self.port);
+
+        Ok(endpoint)
+    }
+}
+
+/// The addresses of the nodes in the network, as stored in files `0:0:101` and `0:0:102`.
+#[derive(Debug, Clone, PartialEq)]
+pub struct NodeAddressBook {
+    pub node_addresses: Vec<NodeAddress>,
+}
+
+impl From<proto::BasicTypes::NodeAddressBook> for NodeAddressBook {
+    fn from(mut book: proto::BasicTypes::NodeAddressBook) -> Self {
+        Self {
+            node_addresses: book.take_nodeAddress().into_iter().map(Into::into).collect(),
+        }
+    }
+}
+
+impl TryFrom<Vec<u8>> for NodeAddressBook {
+    type Err = Error;
+
+    fn try_from(bytes: Vec<u8>) -> Result<Self, Error> {
+        let book: proto::BasicTypes::NodeAddressBook = protobuf::parse_from_bytes(&bytes)?;
+
+        Ok(book.into())
+    }
+}
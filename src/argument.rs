@@ -7,9 +7,9 @@ pub(crate) struct Argument {
 
 impl Argument {
     pub(crate) fn new(val: Vec<u8>, dynam: bool) -> Self {
-        if dynam == false && val.len() != 32 {
-            panic!("ILLEGAL ARGUMENT ERROR: value argument that was not 32 bytes; value was \
-            {:#?} bytes", val.len());
+        if dynam == false && (val.is_empty() || val.len() % 32 != 0) {
+            panic!("ILLEGAL ARGUMENT ERROR: value argument that was not a multiple of 32 bytes; \
+            value was {:#?} bytes", val.len());
         }
         Self {
             value: val,
@@ -14,6 +14,13 @@ pub(crate) fn check_int_width(width: usize) {
     }
 }
 
+pub(crate) fn check_int_bytes_len(bytes: &[u8], width: usize) {
+    if bytes.len() > width / 8 {
+        panic!("ILLEGAL ARGUMENT ERROR: {:#?} bytes do not fit in a Solidity integer of width \
+        {:#?}", bytes.len(), width);
+    }
+}
+
 pub(crate) fn create_padding() -> Vec<u8> {
     let pad = vec![0u8; 32];
     pad
@@ -86,6 +93,27 @@ pub(crate) fn encode_byte_array(byte_array: Vec<Vec<u8>>, prepend_len: bool) ->
     bytes
 }
 
+// Arrays of dynamic elements (string, bytes, dynamic tuples) place an offset to each element,
+// relative to the first offset, before the elements themselves
+pub(crate) fn encode_dynamic_array(elements: Vec<Vec<u8>>, prepend_len: bool) -> Vec<u8> {
+    let mut offsets = Vec::new();
+    let mut bytes = Vec::new();
+    let mut offset = elements.len() * 32;
+    for e in elements.iter() {
+        offsets.extend(int256(offset as isize));
+        offset += e.len();
+        bytes.extend(e);
+    }
+    offsets.extend(bytes);
+
+    if prepend_len == true {
+        let mut enc_bytes = int256(elements.len() as isize);
+        enc_bytes.extend(offsets);
+        return enc_bytes
+    }
+    offsets
+}
+
 pub(crate) fn encode_int_array(int_array: Vec<isize>, int_width: usize, prepend_len: bool) -> Vec<u8> {
     check_int_width(int_width);
 
@@ -8,9 +8,28 @@ use hex;
 #[derive(Clone)]
 pub struct CallParams {
     pub(crate) func_selector: Option<FunctionSelector>,
+    pub(crate) param_types: Vec<String>,
     pub(crate) args: Vec<Argument>
 }
 
+// Check that every tuple in an array has the same type and return that type, and whether
+// the tuples are dynamic
+fn check_tuple_array(tuples: &[CallParams]) -> (String, bool) {
+    let param_type = match tuples.first() {
+        Some(tuple) => tuple.tuple_type(),
+        None => panic!("ILLEGAL ARGUMENT ERROR: a tuple array must have at least one element"),
+    };
+
+    for tuple in tuples {
+        if tuple.tuple_type() != param_type {
+            panic!("ILLEGAL ARGUMENT ERROR: tuple array elements must all be of type {}; \
+            found {}", param_type, tuple.tuple_type());
+        }
+    }
+
+    (param_type, tuples[0].is_dynamic())
+}
+
 impl CallParams {
     pub fn new(func: Option<String>) -> Self {
         let fs = match func {
@@ -20,11 +39,13 @@ impl CallParams {
         let a = Vec::new();
         Self {
             func_selector: fs,
+            param_types: Vec::new(),
             args: a
         }
     }
 
     fn add_param_type(&mut self, param_type: String) {
+        self.param_types.push(param_type.clone());
         match self.func_selector.clone() {
             Some(mut fs) => {
                 fs.add_param_type(param_type);
@@ -49,7 +70,7 @@ impl CallParams {
             bytes.push(es);
         }
 
-        let arg_bytes = encode_byte_array(bytes, true);
+        let arg_bytes = encode_dynamic_array(bytes, true);
         let arg = Argument::new(arg_bytes, true);
 
         self.add_param_type("string[]".to_string());
@@ -65,7 +86,7 @@ impl CallParams {
             bytes.push(es);
         }
 
-        let arg_bytes = encode_byte_array(bytes, true);
+        let arg_bytes = encode_dynamic_array(bytes, false);
         let arg = Argument::new(arg_bytes, true);
 
         let param_type = format!("string[{:#?}]", fixed_len);
@@ -102,7 +123,7 @@ impl CallParams {
             bytes.push(be);
         }
 
-        let arg_bytes = encode_byte_array(bytes, true);
+        let arg_bytes = encode_dynamic_array(bytes, true);
         let arg = Argument::new(arg_bytes, true);
         self.add_param_type("bytes[]".to_string());
         self.args.push(arg);
@@ -140,7 +161,7 @@ impl CallParams {
             bytes.push(be);
         }
 
-        let arg_bytes = encode_byte_array(bytes, true);
+        let arg_bytes = encode_dynamic_array(bytes, false);
         let arg = Argument::new(arg_bytes, true);
         let param_type = format!("bytes[{:#?}]", fixed_len);
         self.add_param_type(param_type);
@@ -233,6 +254,53 @@ impl CallParams {
         self.args.push(arg);
     }
 
+    pub fn add_bool_array(&mut self, param: Vec<bool>) {
+        let mut bytes = Vec::new();
+        for b in param {
+            bytes.push(int256(b as isize));
+        }
+
+        let arg_bytes = encode_byte_array(bytes, true);
+        let arg = Argument::new(arg_bytes, true);
+        self.add_param_type("bool[]".to_string());
+        self.args.push(arg);
+    }
+
+    /// Add a signed integer of up to 256 bits, given as big-endian two's complement bytes.
+    ///
+    /// # Panics
+    ///
+    /// If `param` is empty or longer than `width` bits, or `width` is not a multiple of 8
+    /// from 8 to 256.
+    pub fn add_big_int(&mut self, param: Vec<u8>, width: usize) {
+        check_int_width(width);
+        check_int_bytes_len(&param[..], width);
+
+        let negative = param.first().map_or(false, |b| b & 0x80 != 0);
+        let enc_int = left_pad(param, negative);
+        let arg = Argument::new(enc_int, false);
+        let param_type = format!("int{:#?}", width);
+        self.add_param_type(param_type);
+        self.args.push(arg);
+    }
+
+    /// Add an unsigned integer of up to 256 bits, given as big-endian bytes.
+    ///
+    /// # Panics
+    ///
+    /// If `param` is empty or longer than `width` bits, or `width` is not a multiple of 8
+    /// from 8 to 256.
+    pub fn add_big_uint(&mut self, param: Vec<u8>, width: usize) {
+        check_int_width(width);
+        check_int_bytes_len(&param[..], width);
+
+        let enc_uint = left_pad(param, false);
+        let arg = Argument::new(enc_uint, false);
+        let param_type = format!("uint{:#?}", width);
+        self.add_param_type(param_type);
+        self.args.push(arg);
+    }
+
     pub fn add_address(&mut self, addr: Vec<u8>) {
         check_address_len(addr.clone());
 
@@ -353,19 +421,61 @@ impl CallParams {
         self.add_function(a_bytes, fs[..4].to_vec())
     }
 
-    pub fn assemble(&self) -> Vec<u8> {
-        let mut dynamic_offset = self.args.len() * 32;
-        let mut param_bytes = Vec::new();
+    /// Add a tuple (struct) built from the parameters of another `CallParams`. The function
+    /// name of `tuple`, if any, is ignored.
+    ///
+    /// # Panics
+    ///
+    /// If `tuple` has no parameters.
+    pub fn add_tuple(&mut self, tuple: &CallParams) {
+        let arg = Argument::new(tuple.encode_args(), tuple.is_dynamic());
+        self.add_param_type(tuple.tuple_type());
+        self.args.push(arg);
+    }
 
-        match self.func_selector.clone() {
-            Some(fs) => {
-                let f = fs.finish_intermediate();
-                param_bytes.push(f[..4].to_vec());
-                ()
-            },
-            None => (),
+    /// Add an array of tuples (structs); every tuple must have the same parameter types.
+    pub fn add_tuple_array(&mut self, tuples: Vec<CallParams>) {
+        let (param_type, dynamic) = check_tuple_array(&tuples[..]);
+
+        let bytes = tuples.iter().map(CallParams::encode_args).collect();
+        let arg_bytes = if dynamic {
+            encode_dynamic_array(bytes, true)
+        } else {
+            encode_byte_array(bytes, true)
         };
 
+        let arg = Argument::new(arg_bytes, true);
+        self.add_param_type(format!("{}[]", param_type));
+        self.args.push(arg);
+    }
+
+    /// The 4-byte selector of the function being called, if a function name was given.
+    pub fn function_selector(&self) -> Option<Vec<u8>> {
+        self.func_selector.as_ref().map(|fs| fs.finish_intermediate()[..4].to_vec())
+    }
+
+    pub fn assemble(&self) -> Vec<u8> {
+        let mut out = self.function_selector().unwrap_or_default();
+        out.extend(self.encode_args());
+        out
+    }
+
+    fn tuple_type(&self) -> String {
+        format!("({})", self.param_types.join(","))
+    }
+
+    fn is_dynamic(&self) -> bool {
+        self.args.iter().any(|arg| arg.dynamic)
+    }
+
+    // Encode the arguments alone, without the function selector; this is also the
+    // encoding of a tuple
+    fn encode_args(&self) -> Vec<u8> {
+        // static arguments (e.g. static tuples) may be wider than one word
+        let mut dynamic_offset = self.args.iter()
+            .map(|arg| if arg.dynamic { 32 } else { arg.value.len() })
+            .sum::<usize>();
+        let mut param_bytes = Vec::new();
         let mut dynamic_bytes = Vec::new();
 
         for arg in self.args.clone() {
@@ -457,6 +567,36 @@ mod tests {
 //        assert_eq!(params, correct);
 //    }
 
+    #[test]
+    fn test_tuple_encoding() {
+        let dynamic_correct = "00000000000000000000000000000000000000000000000000000000000000\
+        200000000000000000000000000000000000000000000000000000000000000001000000000000000000000000\
+        000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000\
+        000000000000026869000000000000000000000000000000000000000000000000000000000000".to_string();
+
+        let static_correct = "0000000000000000000000000000000000000000000000000000000000000001\
+        00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000\
+        000000000000000000000000000000000000002".to_string();
+
+        let mut tuple = CallParams::new(None);
+        tuple.add_uint(1, 256);
+        tuple.add_string("hi".to_string());
+        assert_eq!(tuple.tuple_type(), "(uint256,string)");
+
+        let mut cp = CallParams::new(None);
+        cp.add_tuple(&tuple);
+        assert_eq!(hex::encode(cp.assemble()), dynamic_correct);
+
+        let mut tuple = CallParams::new(None);
+        tuple.add_uint(1, 32);
+        tuple.add_bool(true);
+
+        let mut cp = CallParams::new(None);
+        cp.add_tuple(&tuple);
+        cp.add_uint(2, 256);
+        assert_eq!(hex::encode(cp.assemble()), static_correct);
+    }
+
     #[test]
     fn test_array_encodings() {
         let correct = "08712407".to_string();
@@ -1,22 +1,33 @@
 use crate::{
     crypto::SecretKey,
-    id::{ContractId, FileId},
+    grpc_web::GrpcWebClient,
+    id::{ContractId, FileId, ScheduleId},
+    mirror::{MirrorAddressBookQuery, MirrorClient, MirrorNodeContractQuery},
     proto::{
-        CryptoService_grpc::CryptoServiceClient, FileService_grpc::FileServiceClient,
-        SmartContractService_grpc::SmartContractServiceClient,
+        AddressBookService_grpc::AddressBookServiceClient, CryptoService_grpc::CryptoServiceClient,
+        FileService_grpc::FileServiceClient, FreezeService_grpc::FreezeServiceClient,
+        MirrorNetworkService_grpc::NetworkServiceClient as MirrorNetworkServiceClient,
+        NetworkService_grpc::NetworkServiceClient, ScheduleService_grpc::ScheduleServiceClient,
+        SmartContractService_grpc::SmartContractServiceClient, UtilService_grpc::UtilServiceClient,
     },
     query::{
-        Query, QueryCryptoGetAccountBalance, QueryCryptoGetClaim, QueryCryptoGetInfo,
-        QueryFileGetContents, QueryFileGetInfo, QueryTransactionGetReceipt,
-        QueryTransactionGetRecord,
+        Query, QueryContractCall, QueryContractGetBytecode, QueryContractGetInfo,
+        QueryCryptoGetAccountBalance, QueryCryptoGetClaim, QueryCryptoGetInfo,
+        QueryFileGetContents, QueryFileGetContentsAs, QueryFileGetInfo, QueryNetworkGetVersionInfo,
+        QueryScheduleGetInfo, QueryTransactionGetReceipt, QueryTransactionGetRecord,
     },
     transaction::{
-        Transaction, TransactionContractCall, TransactionContractCreate, TransactionContractUpdate,
-        TransactionContractDelete, TransactionCryptoCreate, TransactionCryptoDelete,
-        TransactionCryptoDeleteClaim, TransactionCryptoTransfer, TransactionCryptoUpdate,
-        TransactionFileAppend, TransactionFileCreate, TransactionFileDelete,
+        FreezeType, Transaction, TransactionBatch, TransactionContractCall,
+        TransactionContractCreate, TransactionContractUpdate, TransactionContractDelete,
+        TransactionCryptoCreate, TransactionCryptoDelete, TransactionCryptoDeleteClaim,
+        TransactionCryptoTransfer, TransactionCryptoUpdate, TransactionEthereum,
+        TransactionFileAppend, TransactionFileCreate, TransactionFileDelete, TransactionFreeze,
+        TransactionNodeCreate, TransactionNodeDelete, TransactionNodeUpdate, TransactionPrng,
+        TransactionRaw, TransactionScheduleCreate, TransactionScheduleDelete,
+        TransactionScheduleSign, TransactionSystemDelete, TransactionSystemUndelete,
     },
-    AccountId, TransactionId,
+    AccountId, ContractCreateFlow, ErrorKind, EthereumFlow, ExchangeRates, FeeSchedules,
+    NodeAddressBook, Observer, Status, TransactionId,
 };
 use failure::{err_msg, format_err, Error};
 use grpc::ClientStub;
@@ -29,8 +40,21 @@ pub struct ClientBuilder<'a> {
     node: Option<AccountId>,
     operator: Option<AccountId>,
     operator_secret: Option<Arc<dyn Fn() -> Result<SecretKey, Error> + Send + Sync>>,
+    mirror_node: Option<&'a str>,
+    mirror_network: Option<&'a str>,
+    grpc_web: Option<&'a str>,
 }
 
+/// A connection to a node of the Hedera network, along with the operator that pays for
+/// transactions and queries.
+///
+/// A client is `Send` and `Sync` and is meant to be shared, e.g. behind an `Arc`, by every
+/// task or thread of a program. The services it holds are reference counted and safe to call
+/// concurrently, and its settings can only be changed through `&mut self`, so no request ever
+/// sees a half-applied setting.
+///
+/// The blocking methods, such as `execute` and `get`, take turns on one shared runtime. Use
+/// the `_async` methods to run many requests at once.
 pub struct Client {
     pub(crate) node: Option<AccountId>,
     pub(crate) operator: Option<AccountId>,
@@ -38,6 +62,20 @@ pub struct Client {
     pub(crate) crypto: Arc<CryptoServiceClient>,
     pub(crate) file: Arc<FileServiceClient>,
     pub(crate) contract: Arc<SmartContractServiceClient>,
+    pub(crate) schedule: Arc<ScheduleServiceClient>,
+    pub(crate) freeze: Arc<FreezeServiceClient>,
+    pub(crate) address_book: Arc<AddressBookServiceClient>,
+    pub(crate) util: Arc<UtilServiceClient>,
+    pub(crate) network: Arc<NetworkServiceClient>,
+    pub(crate) mirror: Option<Arc<MirrorClient>>,
+    pub(crate) mirror_network: Option<Arc<MirrorNetworkServiceClient>>,
+    pub(crate) mirror_network_address: Option<String>,
+    pub(crate) regenerate_transaction_id: bool,
+    pub(crate) max_query_payment: Option<u64>,
+    pub(crate) mirror_receipt_fallback: bool,
+    pub(crate) observer: Option<Arc<dyn Observer>>,
+    pub(crate) retryable: Arc<dyn Fn(Status) -> bool + Send + Sync>,
+    pub(crate) web: Option<Arc<GrpcWebClient>>,
 }
 
 impl<'a> ClientBuilder<'a> {
@@ -61,6 +99,26 @@ impl<'a> ClientBuilder<'a> {
         self
     }
 
+    /// Sets the base URL of the mirror node REST API, e.g. `https://testnet.mirrornode.hedera.com`.
+    pub fn mirror_node(mut self, url: &'a str) -> Self {
+        self.mirror_node = Some(url);
+        self
+    }
+
+    /// Sets the `host:port` address of the mirror node gRPC API,
+    /// e.g. `testnet.mirrornode.hedera.com:443`. TLS is used for port 443.
+    pub fn mirror_network(mut self, address: &'a str) -> Self {
+        self.mirror_network = Some(address);
+        self
+    }
+
+    /// Sends transactions and queries through the grpc-web proxy at `url`; see
+    /// `Client::set_grpc_web`.
+    pub fn grpc_web(mut self, url: &'a str) -> Self {
+        self.grpc_web = Some(url);
+        self
+    }
+
     pub fn build(self) -> Result<Client, Error> {
         let mut client = Client::new(&self.address)?;
 
@@ -68,6 +126,18 @@ impl<'a> ClientBuilder<'a> {
             client.set_node(node);
         }
 
+        if let Some(url) = self.mirror_node {
+            client.set_mirror_node(url);
+        }
+
+        if let Some(address) = self.mirror_network {
+            client.set_mirror_network(address)?;
+        }
+
+        if let Some(url) = self.grpc_web {
+            client.set_grpc_web(url);
+        }
+
         if let (Some(operator), Some(secret)) = (self.operator, self.operator_secret) {
             client.operator = Some(operator);
             client.operator_secret = Some(secret);
@@ -84,52 +154,127 @@ impl Client {
             node: None,
             operator: None,
             operator_secret: None,
+            mirror_node: None,
+            mirror_network: None,
+            grpc_web: None,
         }
     }
 
     pub fn new(address: impl AsRef<str>) -> Result<Self, Error> {
         let address = address.as_ref();
-        let (host, port) = address.split(':').next_tuple().ok_or_else(|| {
-            format_err!("failed to parse 'host:port' from address: {:?}", address)
-        })?;
-
-        let port = port.parse()?;
-
-        let inner = Arc::new(grpc::Client::new_plain(
-            &host,
-            port,
-            grpc::ClientConf {
-                http: httpbis::ClientConf {
-                    no_delay: Some(true),
-                    connection_timeout: Some(Duration::from_secs(5)),
-                    ..httpbis::ClientConf::default()
-                },
-            },
-        )?);
+        let inner = Arc::new(connect(address, false)?);
 
         let crypto = Arc::new(CryptoServiceClient::with_client(inner.clone()));
         let file = Arc::new(FileServiceClient::with_client(inner.clone()));
         let contract = Arc::new(SmartContractServiceClient::with_client(inner.clone()));
+        let schedule = Arc::new(ScheduleServiceClient::with_client(inner.clone()));
+        let freeze = Arc::new(FreezeServiceClient::with_client(inner.clone()));
+        let address_book = Arc::new(AddressBookServiceClient::with_client(inner.clone()));
+        let util = Arc::new(UtilServiceClient::with_client(inner.clone()));
+        let network = Arc::new(NetworkServiceClient::with_client(inner.clone()));
 
-        // Default the node to what we know every testnet is on
-        let node = if address.starts_with("testnet.") {
-            Some(AccountId {
-                shard: 0,
-                realm: 0,
-                account: 3,
-            })
-        } else {
-            None
-        };
-
-        Ok(Self {
-            node,
+        let mut client = Self {
+            node: None,
             operator: None,
             operator_secret: None,
             crypto,
             file,
             contract,
-        })
+            schedule,
+            freeze,
+            address_book,
+            util,
+            network,
+            mirror: None,
+            mirror_network: None,
+            mirror_network_address: None,
+            regenerate_transaction_id: true,
+            max_query_payment: None,
+            mirror_receipt_fallback: false,
+            observer: None,
+            retryable: Arc::new(is_retryable),
+            web: None,
+        };
+
+        // Default the node and mirror node to what we know every testnet is on
+        if address.starts_with("testnet.") {
+            client.node = Some(AccountId {
+                shard: 0,
+                realm: 0,
+                account: 3,
+            });
+        }
+
+        // Default the mirror node to the public one of the network
+        let mirror = ["testnet", "previewnet", "mainnet"]
+            .iter()
+            .find(|network| address.starts_with(&format!("{}.", network)));
+
+        let mirror = match mirror {
+            Some(&"mainnet") => Some("mainnet-public.mirrornode.hedera.com".to_owned()),
+            Some(network) => Some(format!("{}.mirrornode.hedera.com", network)),
+            None => None,
+        };
+
+        if let Some(mirror) = mirror {
+            client.set_mirror_node(format!("https://{}", mirror));
+
+            // the mirror node is optional; failing to reach it must not fail the client
+            if let Err(error) = client.set_mirror_network(&format!("{}:443", mirror)) {
+                log::warn!(
+                    target: "hedera::mirror",
+                    "cannot connect to the mirror network {}: {}",
+                    mirror,
+                    error
+                );
+            }
+        }
+
+        Ok(client)
+    }
+
+    #[inline]
+    pub fn set_mirror_node(&mut self, url: impl Into<String>) {
+        self.mirror = Some(Arc::new(MirrorClient::new(url)));
+    }
+
+    /// Sets the `host:port` address of the mirror node gRPC API.
+    ///
+    /// The connection uses TLS for port 443, as the public mirror nodes do, and plaintext
+    /// for any other port; use `set_mirror_network_tls` to choose.
+    pub fn set_mirror_network(&mut self, address: &str) -> Result<(), Error> {
+        self.set_mirror_network_tls(address, address.ends_with(":443"))
+    }
+
+    /// Sets the `host:port` address of the mirror node gRPC API, and whether to connect to it
+    /// with TLS; plaintext is for a local or self-hosted mirror node.
+    pub fn set_mirror_network_tls(&mut self, address: &str, tls: bool) -> Result<(), Error> {
+        let inner = Arc::new(connect(address, tls)?);
+        self.mirror_network = Some(Arc::new(MirrorNetworkServiceClient::with_client(inner)));
+        self.mirror_network_address = Some(address.to_owned());
+
+        Ok(())
+    }
+
+    /// The `host:port` address of the mirror node gRPC API, if one was set.
+    #[inline]
+    pub fn mirror_network(&self) -> Option<&str> {
+        self.mirror_network_address.as_ref().map(String::as_str)
+    }
+
+    pub(crate) fn mirror_network_service(&self) -> Result<&MirrorNetworkServiceClient, Error> {
+        match &self.mirror_network {
+            Some(mirror) => Ok(mirror),
+            None => Err(ErrorKind::MissingField("mirror_network"))?,
+        }
+    }
+
+    /// The client for the mirror node REST API, if a mirror node was set.
+    pub fn mirror(&self) -> Result<&MirrorClient, Error> {
+        match &self.mirror {
+            Some(mirror) => Ok(mirror),
+            None => Err(ErrorKind::MissingField("mirror_node"))?,
+        }
     }
 
     #[inline]
@@ -137,6 +282,58 @@ impl Client {
         self.node = Some(node);
     }
 
+    /// The most a query may cost, in tinybars, before it fails instead of being paid for.
+    /// Unlimited by default; can be overridden per query.
+    #[inline]
+    pub fn set_max_query_payment(&mut self, amount: u64) {
+        self.max_query_payment = Some(amount);
+    }
+
+    /// Whether `TransactionResponse::get_receipt` asks the mirror node for the outcome of a
+    /// transaction when the consensus node cannot give its receipt, such as when busy.
+    /// Defaults to `false`; needs a mirror node.
+    #[inline]
+    pub fn set_mirror_receipt_fallback(&mut self, fallback: bool) {
+        self.mirror_receipt_fallback = fallback;
+    }
+
+    /// Report every attempt at a transaction or query to `observer`, e.g. to trace or
+    /// measure traffic to the network. Applies to transactions and queries created after.
+    #[inline]
+    pub fn set_observer(&mut self, observer: impl Observer + 'static) {
+        self.observer = Some(Arc::new(observer));
+    }
+
+    /// Send transactions and queries to the node through the grpc-web proxy at `url`,
+    /// e.g. `https://grpc-web.example.com`, over HTTP/1.1 instead of gRPC. For where HTTP/2
+    /// traffic is blocked, such as behind some proxies.
+    ///
+    /// Applies to transactions and queries created after. Mirror node subscriptions still
+    /// use gRPC.
+    #[inline]
+    pub fn set_grpc_web(&mut self, url: &str) {
+        self.web = Some(Arc::new(GrpcWebClient::new(url)));
+    }
+
+    /// Which precheck statuses a transaction or query is retried on, up to 5 times with a
+    /// growing delay. Defaults to only `Status::Busy`.
+    ///
+    /// Transactions that expire are retried separately; see `set_regenerate_transaction_id`.
+    #[inline]
+    pub fn set_retry_predicate(
+        &mut self,
+        retryable: impl Fn(Status) -> bool + Send + Sync + 'static,
+    ) {
+        self.retryable = Arc::new(retryable);
+    }
+
+    /// Whether transactions that expire before reaching the node are retried with a new
+    /// transaction ID. Defaults to `true`; can be overridden per transaction.
+    #[inline]
+    pub fn set_regenerate_transaction_id(&mut self, regenerate: bool) {
+        self.regenerate_transaction_id = regenerate;
+    }
+
     #[inline]
     pub fn set_operator<R, E>(
         &mut self,
@@ -180,6 +377,13 @@ impl Client {
         TransactionContractCreate::new(self)
     }
 
+    /// Start a new smart contract instance from bytecode of any size, uploading it to a
+    /// temporary file first.
+    #[inline]
+    pub fn create_contract_flow(&self, bytecode: Vec<u8>) -> ContractCreateFlow<'_> {
+        ContractCreateFlow::new(self, bytecode)
+    }
+
     #[inline]
     pub fn call_contract(&self, id: ContractId) -> Transaction<TransactionContractCall> {
         TransactionContractCall::new(self, id)
@@ -195,6 +399,19 @@ impl Client {
         TransactionContractDelete::new(self, id)
     }
 
+    /// Submit a raw, signed Ethereum transaction.
+    #[inline]
+    pub fn ethereum_transaction(&self, ethereum_data: Vec<u8>) -> Transaction<TransactionEthereum> {
+        TransactionEthereum::new(self, ethereum_data)
+    }
+
+    /// Submit a raw, signed Ethereum transaction of any size, moving its call data to a file
+    /// if needed.
+    #[inline]
+    pub fn ethereum_flow(&self, ethereum_data: Vec<u8>) -> EthereumFlow<'_> {
+        EthereumFlow::new(self, ethereum_data)
+    }
+
     #[inline]
     pub fn contract(&self, id: ContractId) -> PartialContractMessage<'_> {
         PartialContractMessage(self, id)
@@ -217,12 +434,160 @@ impl Client {
         PartialFileMessage(self, id)
     }
 
+    /// Freeze or upgrade the network. Must be signed by the Hedera council.
+    #[inline]
+    pub fn freeze(&self, freeze_type: FreezeType) -> Transaction<TransactionFreeze> {
+        TransactionFreeze::new(self, freeze_type)
+    }
+
+    /// Add a node to the network address book.
+    #[inline]
+    pub fn create_node(&self) -> Transaction<TransactionNodeCreate> {
+        TransactionNodeCreate::new(self)
+    }
+
+    /// Update the address book entry of the node with the given ID.
+    #[inline]
+    pub fn update_node(&self, node_id: u64) -> Transaction<TransactionNodeUpdate> {
+        TransactionNodeUpdate::new(self, node_id)
+    }
+
+    /// Remove the node with the given ID from the network address book.
+    #[inline]
+    pub fn delete_node(&self, node_id: u64) -> Transaction<TransactionNodeDelete> {
+        TransactionNodeDelete::new(self, node_id)
+    }
+
+    /// Generate a pseudorandom number, available in the record of the transaction.
+    #[inline]
+    pub fn prng(&self) -> Transaction<TransactionPrng> {
+        TransactionPrng::new(self)
+    }
+
+    /// Execute a batch of inner transactions atomically; either all of them take effect or
+    /// none do. See `Transaction::batchify`.
+    #[inline]
+    pub fn batch(&self) -> Transaction<TransactionBatch> {
+        TransactionBatch::new(self)
+    }
+
+    /// Create a schedule for a transaction, to be executed once it has collected enough
+    /// signatures. Transactions can also be scheduled with `Transaction::schedule`.
+    #[inline]
+    pub fn create_schedule(&self) -> Transaction<TransactionScheduleCreate> {
+        TransactionScheduleCreate::new(self)
+    }
+
+    #[inline]
+    pub fn schedule(&self, id: ScheduleId) -> PartialScheduleMessage<'_> {
+        PartialScheduleMessage(self, id)
+    }
+
+    /// Get the versions of the protobuf API and of Hedera Services deployed on the node, to
+    /// check that the network supports a feature before using it.
+    #[inline]
+    pub fn network_version_info(&self) -> Query<QueryNetworkGetVersionInfo> {
+        QueryNetworkGetVersionInfo::new(self)
+    }
+
+    /// Get the address book of the network, stored in file `0:0:101`.
+    #[inline]
+    pub fn address_book(&self) -> Query<QueryFileGetContentsAs<NodeAddressBook>> {
+        QueryFileGetContentsAs::new(self, FileId::new(0, 0, 101))
+    }
+
+    /// Get the address book of the network from the mirror node, with the service endpoints
+    /// and certificate hashes of each node.
+    #[inline]
+    pub fn mirror_address_book(&self) -> MirrorAddressBookQuery<'_> {
+        MirrorAddressBookQuery::new(self)
+    }
+
+    /// Get the detailed address book of the network nodes, stored in file `0:0:102`.
+    #[inline]
+    pub fn node_details(&self) -> Query<QueryFileGetContentsAs<NodeAddressBook>> {
+        QueryFileGetContentsAs::new(self, FileId::new(0, 0, 102))
+    }
+
+    /// Get the current and next fee schedules, stored in file `0:0:111`.
+    #[inline]
+    pub fn fee_schedules(&self) -> Query<QueryFileGetContentsAs<FeeSchedules>> {
+        QueryFileGetContentsAs::new(self, FileId::new(0, 0, 111))
+    }
+
+    /// Estimate the fee of a transaction in tinybars from the current fee schedule and exchange
+    /// rate of the network, assuming only the operator signs. See `Transaction::estimate_fee`.
+    pub async fn estimate_fee_async<T: 'static>(
+        &self,
+        tx: &mut Transaction<T>,
+    ) -> Result<u64, Error> {
+        let schedules = self.fee_schedules().get_async().await?;
+        let rates = self.exchange_rates().get_async().await?;
+
+        let schedule = schedules
+            .current
+            .ok_or_else(|| err_msg("network has no current fee schedule"))?;
+
+        tx.estimate_fee(&schedule, &rates.current, 1)
+    }
+
+    /// Estimate the fee of a transaction in tinybars, querying the fee schedule and exchange
+    /// rate of the network. See `estimate_fee_async`.
+    pub fn estimate_fee<T: 'static>(&self, tx: &mut Transaction<T>) -> Result<u64, Error> {
+        crate::RUNTIME.lock().block_on(self.estimate_fee_async(tx))
+    }
+
+    /// Get the current and next exchange rates, stored in file `0:0:112`.
+    #[inline]
+    pub fn exchange_rates(&self) -> Query<QueryFileGetContentsAs<ExchangeRates>> {
+        QueryFileGetContentsAs::new(self, FileId::new(0, 0, 112))
+    }
+
+    /// Deserialize a signed transaction so it can be signed further or executed through this
+    /// client. See `Transaction::to_bytes`.
+    #[inline]
+    pub fn transaction_from_bytes(
+        &self,
+        bytes: &[u8],
+    ) -> Result<Transaction<(), TransactionRaw>, Error> {
+        Transaction::from_bytes(self, bytes)
+    }
+
     #[inline]
     pub fn transaction(&self, id: TransactionId) -> PartialTransactionMessage {
         PartialTransactionMessage(self, id)
     }
 }
 
+// The default of `set_retry_predicate`
+pub(crate) fn is_retryable(status: Status) -> bool {
+    status == Status::Busy
+}
+
+// Open a gRPC connection to a `host:port` address
+fn connect(address: &str, tls: bool) -> Result<grpc::Client, Error> {
+    let (host, port) = address
+        .split(':')
+        .next_tuple()
+        .ok_or_else(|| format_err!("failed to parse 'host:port' from address: {:?}", address))?;
+
+    let port = port.parse()?;
+
+    let conf = grpc::ClientConf {
+        http: httpbis::ClientConf {
+            no_delay: Some(true),
+            connection_timeout: Some(Duration::from_secs(5)),
+            ..httpbis::ClientConf::default()
+        },
+    };
+
+    Ok(if tls {
+        grpc::Client::new_tls::<tls_api_native_tls::TlsConnector>(&host, port, conf)?
+    } else {
+        grpc::Client::new_plain(&host, port, conf)?
+    })
+}
+
 pub struct PartialAccountMessage<'a>(&'a Client, AccountId);
 
 impl<'a> PartialAccountMessage<'a> {
@@ -287,6 +652,22 @@ impl<'a> PartialFileMessage<'a> {
         TransactionFileDelete::new(self.0, self.1)
     }
 
+    /// Delete the file as a Hedera administrator.
+    #[inline]
+    pub fn system_delete(self) -> Transaction<TransactionSystemDelete> {
+        let mut tx = TransactionSystemDelete::new(self.0);
+        tx.file(self.1);
+        tx
+    }
+
+    /// Restore a file removed by a system delete.
+    #[inline]
+    pub fn system_undelete(self) -> Transaction<TransactionSystemUndelete> {
+        let mut tx = TransactionSystemUndelete::new(self.0);
+        tx.file(self.1);
+        tx
+    }
+
     #[inline]
     pub fn info(self) -> Query<QueryFileGetInfo> {
         QueryFileGetInfo::new(self.0, self.1)
@@ -306,10 +687,83 @@ impl<'a> PartialContractMessage<'a> {
         TransactionContractCall::new(self.0, self.1)
     }
 
+    /// Call a function of the contract locally on the node, without changing its state.
+    #[inline]
+    pub fn call_local(self) -> Query<QueryContractCall> {
+        QueryContractCall::new(self.0, self.1)
+    }
+
+    /// Call a function of the contract, or estimate its gas, through the mirror node instead
+    /// of a paid query to a consensus node.
+    #[inline]
+    pub fn mirror_call(self) -> MirrorNodeContractQuery<'a> {
+        MirrorNodeContractQuery::new(self.0, self.1)
+    }
+
+    /// Get information about the contract instance, including its admin key, expiration,
+    /// storage usage, balance, and token relationships.
+    #[inline]
+    pub fn info(self) -> Query<QueryContractGetInfo> {
+        QueryContractGetInfo::new(self.0, self.1)
+    }
+
+    /// Get the runtime bytecode of the contract instance.
+    #[inline]
+    pub fn bytecode(self) -> Query<QueryContractGetBytecode> {
+        QueryContractGetBytecode::new(self.0, self.1)
+    }
+
     #[inline]
     pub fn update(self) -> Transaction<TransactionContractUpdate> {
         TransactionContractUpdate::new(self.0, self.1)
     }
+
+    /// Delete the contract, transferring its remaining hbars to the obtainer set on the
+    /// transaction.
+    #[inline]
+    pub fn delete(self) -> Transaction<TransactionContractDelete> {
+        TransactionContractDelete::new(self.0, self.1)
+    }
+
+    /// Delete the contract as a Hedera administrator.
+    #[inline]
+    pub fn system_delete(self) -> Transaction<TransactionSystemDelete> {
+        let mut tx = TransactionSystemDelete::new(self.0);
+        tx.contract(self.1);
+        tx
+    }
+
+    /// Restore a contract removed by a system delete.
+    #[inline]
+    pub fn system_undelete(self) -> Transaction<TransactionSystemUndelete> {
+        let mut tx = TransactionSystemUndelete::new(self.0);
+        tx.contract(self.1);
+        tx
+    }
+}
+
+pub struct PartialScheduleMessage<'a>(&'a Client, ScheduleId);
+
+impl<'a> PartialScheduleMessage<'a> {
+    /// Add the signatures of the transaction to the schedule.
+    #[inline]
+    pub fn sign(self) -> Transaction<TransactionScheduleSign> {
+        TransactionScheduleSign::new(self.0, self.1)
+    }
+
+    /// Delete the schedule before the scheduled transaction executes. Must be signed by the
+    /// admin key of the schedule.
+    #[inline]
+    pub fn delete(self) -> Transaction<TransactionScheduleDelete> {
+        TransactionScheduleDelete::new(self.0, self.1)
+    }
+
+    /// Get the current state of the schedule, including the signatures it has collected and
+    /// the transaction it will execute.
+    #[inline]
+    pub fn info(self) -> Query<QueryScheduleGetInfo> {
+        QueryScheduleGetInfo::new(self.0, self.1)
+    }
 }
 
 pub struct PartialTransactionMessage<'a>(&'a Client, TransactionId);
@@ -333,3 +787,15 @@ impl<'a> PartialTransactionMessage<'a> {
         QueryTransactionGetRecord::new(self.0, self.1)
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use super::Client;
+
+    fn assert_send_sync<T: Send + Sync>() {}
+
+    #[test]
+    fn test_client_is_send_sync() {
+        assert_send_sync::<Client>();
+    }
+}
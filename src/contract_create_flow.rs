@@ -0,0 +1,233 @@
+use crate::{
+    crypto::PublicKey, transaction::TransactionContractCreate, AccountId, Client, ErrorKind,
+    FileId, TransactionReceipt,
+};
+use failure::{format_err, Error};
+use std::time::Duration;
+
+// Files are limited by the maximum transaction size; leave room for the rest of the body
+const CHUNK_SIZE: usize = 4096;
+
+/// Create a smart contract from bytecode of any size.
+///
+/// The bytecode is uploaded to a temporary file with a file create and as many file appends as
+/// needed, the contract is created from that file, and the file is deleted afterwards. The file
+/// is owned by the operator of the client.
+pub struct ContractCreateFlow<'a> {
+    client: &'a Client,
+    bytecode: Vec<u8>,
+    gas: i64,
+    admin_key: Option<PublicKey>,
+    initial_balance: i64,
+    auto_renew_period: Option<Duration>,
+    constructor_parameters: Option<Vec<u8>>,
+    memo: Option<String>,
+    max_automatic_token_associations: i32,
+    auto_renew_account: Option<AccountId>,
+    staked_account: Option<AccountId>,
+    staked_node: Option<i64>,
+    decline_staking_reward: bool,
+}
+
+impl<'a> ContractCreateFlow<'a> {
+    pub fn new(client: &'a Client, bytecode: Vec<u8>) -> Self {
+        Self {
+            client,
+            bytecode,
+            gas: 0,
+            admin_key: None,
+            initial_balance: 0,
+            auto_renew_period: None,
+            constructor_parameters: None,
+            memo: None,
+            max_automatic_token_associations: 0,
+            auto_renew_account: None,
+            staked_account: None,
+            staked_node: None,
+            decline_staking_reward: false,
+        }
+    }
+
+    #[inline]
+    pub fn gas(&mut self, gas: i64) -> &mut Self {
+        self.gas = gas;
+        self
+    }
+
+    #[inline]
+    pub fn admin_key(&mut self, key: PublicKey) -> &mut Self {
+        self.admin_key = Some(key);
+        self
+    }
+
+    #[inline]
+    pub fn initial_balance(&mut self, balance: i64) -> &mut Self {
+        self.initial_balance = balance;
+        self
+    }
+
+    #[inline]
+    pub fn auto_renew_period(&mut self, period: Duration) -> &mut Self {
+        self.auto_renew_period = Some(period);
+        self
+    }
+
+    #[inline]
+    pub fn constructor_parameters(&mut self, params: Vec<u8>) -> &mut Self {
+        self.constructor_parameters = Some(params);
+        self
+    }
+
+    #[inline]
+    pub fn contract_memo(&mut self, memo: impl Into<String>) -> &mut Self {
+        self.memo = Some(memo.into());
+        self
+    }
+
+    #[inline]
+    pub fn max_automatic_token_associations(&mut self, max: i32) -> &mut Self {
+        self.max_automatic_token_associations = max;
+        self
+    }
+
+    #[inline]
+    pub fn auto_renew_account(&mut self, account: AccountId) -> &mut Self {
+        self.auto_renew_account = Some(account);
+        self
+    }
+
+    /// Stake the contract to an account. Replaces any staked node.
+    #[inline]
+    pub fn staked_account(&mut self, account: AccountId) -> &mut Self {
+        self.staked_node = None;
+        self.staked_account = Some(account);
+        self
+    }
+
+    /// Stake the contract to a node. Replaces any staked account.
+    #[inline]
+    pub fn staked_node(&mut self, node: i64) -> &mut Self {
+        self.staked_account = None;
+        self.staked_node = Some(node);
+        self
+    }
+
+    #[inline]
+    pub fn decline_staking_reward(&mut self, decline: bool) -> &mut Self {
+        self.decline_staking_reward = decline;
+        self
+    }
+
+    /// Run every step of the flow and return the receipt of the contract create.
+    pub async fn execute_async(&self) -> Result<TransactionReceipt, Error> {
+        // File backed contracts are stored as hex
+        let contents = hex::encode(&self.bytecode).into_bytes();
+        let file = upload_file(self.client, &contents).await?;
+
+        let created = self.create_contract(file).await;
+        let deleted = delete_file(self.client, file).await;
+
+        let receipt = created?;
+        deleted?;
+
+        Ok(receipt)
+    }
+
+    pub fn execute(&self) -> Result<TransactionReceipt, Error> {
+        crate::RUNTIME.lock().block_on(self.execute_async())
+    }
+
+    async fn create_contract(&self, file: FileId) -> Result<TransactionReceipt, Error> {
+        let mut tx = TransactionContractCreate::new(self.client);
+
+        tx.file(file)
+            .gas(self.gas)
+            .initial_balance(self.initial_balance)
+            .max_automatic_token_associations(self.max_automatic_token_associations)
+            .decline_staking_reward(self.decline_staking_reward);
+
+        if let Some(key) = &self.admin_key {
+            tx.admin_key(key.clone());
+        }
+
+        if let Some(period) = self.auto_renew_period {
+            tx.auto_renew_period(period);
+        }
+
+        if let Some(params) = &self.constructor_parameters {
+            tx.constructor_parameters(params.clone());
+        }
+
+        if let Some(memo) = &self.memo {
+            tx.contract_memo(memo.clone());
+        }
+
+        if let Some(account) = self.auto_renew_account {
+            tx.auto_renew_account(account);
+        }
+
+        if let Some(account) = self.staked_account {
+            tx.staked_account(account);
+        }
+
+        if let Some(node) = self.staked_node {
+            tx.staked_node(node);
+        }
+
+        tx.execute_async().await?.get_receipt_async(self.client).await
+    }
+}
+
+// Create a file owned by the operator of the client holding `contents`, using as many file
+// appends as needed
+pub(crate) async fn upload_file(client: &Client, contents: &[u8]) -> Result<FileId, Error> {
+    let secret = match &client.operator_secret {
+        Some(secret) => secret,
+        None => Err(ErrorKind::MissingField("operator"))?,
+    };
+
+    let key = secret()?.public();
+    let mut chunks = contents.chunks(CHUNK_SIZE);
+
+    let receipt = client
+        .create_file()
+        .key(key)
+        .contents(chunks.next().unwrap_or_default().to_vec())
+        .execute_async()
+        .await?
+        .get_receipt_async(client)
+        .await?;
+
+    let file = match receipt.file_id {
+        Some(file) => *file,
+        None => Err(format_err!("file create receipt did not contain a file"))?,
+    };
+
+    for chunk in chunks {
+        let appended = match client.append_file(file, chunk.to_vec()).execute_async().await {
+            Ok(response) => response.get_receipt_async(client).await,
+            Err(error) => Err(error),
+        };
+
+        if let Err(error) = appended {
+            // Do not leave the partial upload behind
+            delete_file(client, file).await.ok();
+            return Err(error);
+        }
+    }
+
+    Ok(file)
+}
+
+pub(crate) async fn delete_file(
+    client: &Client,
+    file: FileId,
+) -> Result<TransactionReceipt, Error> {
+    client
+        .file(file)
+        .delete()
+        .execute_async()
+        .await?
+        .get_receipt_async(client)
+        .await
+}
@@ -1,4 +1,7 @@
-use crate::proto::{self, ToProto};
+use crate::{
+    proto::{self, ToProto},
+    ContractId,
+};
 use bip39::{Language, Mnemonic, MnemonicType, Seed};
 use ed25519_dalek;
 use failure::{bail, err_msg, Error};
@@ -365,6 +368,58 @@ impl TryFrom<proto::BasicTypes::Key> for PublicKey {
     }
 }
 
+/// Any key that can be set on an entity or required to sign for it, including keys made of
+/// other keys.
+///
+/// Most entities have a single `Ed25519` key; this covers the others, such as those created by
+/// other SDKs.
+#[derive(Debug, PartialEq, Clone)]
+pub enum Key {
+    Ed25519(PublicKey),
+    /// A contract that is authorized as if it had signed.
+    Contract(ContractId),
+    Rsa3072(Vec<u8>),
+    EcdsaP384(Vec<u8>),
+    /// Any `threshold` of `keys` must sign.
+    Threshold { threshold: u32, keys: Vec<Key> },
+    /// Every key of the list must sign.
+    List(Vec<Key>),
+}
+
+impl TryFrom<proto::BasicTypes::Key> for Key {
+    type Err = Error;
+
+    fn try_from(mut key: proto::BasicTypes::Key) -> Result<Self, Self::Err> {
+        use self::proto::BasicTypes::Key_oneof_key::*;
+
+        Ok(match key.key.take() {
+            Some(ed25519(bytes)) => {
+                let mut key = proto::BasicTypes::Key::new();
+                key.set_ed25519(bytes);
+
+                Key::Ed25519(key.try_into()?)
+            }
+
+            Some(contractID(id)) => Key::Contract(id.into()),
+            Some(RSA_3072(bytes)) => Key::Rsa3072(bytes),
+            Some(ECDSA_384(bytes)) => Key::EcdsaP384(bytes),
+
+            Some(thresholdKey(mut threshold)) => Key::Threshold {
+                threshold: threshold.get_threshold(),
+                keys: keys_from_proto(threshold.take_keys())?,
+            },
+
+            Some(keyList(list)) => Key::List(keys_from_proto(list)?),
+
+            None => Err(err_msg("key is empty"))?,
+        })
+    }
+}
+
+fn keys_from_proto(list: proto::BasicTypes::KeyList) -> Result<Vec<Key>, Error> {
+    list.keys.into_iter().map(TryInto::try_into).collect()
+}
+
 /// An EdDSA secret key.
 #[repr(C)]
 pub struct SecretKey(ed25519_dalek::SecretKey);
@@ -526,7 +581,7 @@ impl Display for SecretKey {
 }
 
 /// An EdDSA signature.
-#[derive(Debug)]
+#[derive(Debug, Clone)]
 #[repr(C)]
 pub struct Signature(ed25519_dalek::Signature);
 
@@ -589,8 +644,13 @@ impl<'a> ToProto<proto::BasicTypes::Signature> for &'a [&'a Signature] {
 
 #[cfg(test)]
 mod tests {
-    use super::{PublicKey, SecretKey, Signature};
+    use super::{Key, PublicKey, SecretKey, Signature};
+    use crate::{
+        proto::{self, ToProto},
+        ContractId,
+    };
     use failure::Error;
+    use try_from::TryInto;
 
     const KEY_PUBLIC_ASN1_HEX: &str =
         "302a300506032b6570032100e0c8ec2758a5879ffac226a13c0c516b799e72e35141a0dd828f94d37988a4b7";
@@ -678,4 +738,36 @@ mod tests {
 
         Ok(())
     }
+
+    #[test]
+    fn test_key_from_proto() -> Result<(), Error> {
+        let public: PublicKey = KEY_PUBLIC_HEX.parse()?;
+        let contract = ContractId::new(0, 0, 1001);
+
+        let mut contract_key = proto::BasicTypes::Key::new();
+        contract_key.set_contractID(contract.to_proto()?);
+
+        let mut list = proto::BasicTypes::KeyList::new();
+        list.keys.push(public.to_proto()?);
+        list.keys.push(contract_key);
+
+        let mut threshold = proto::BasicTypes::ThresholdKey::new();
+        threshold.set_threshold(1);
+        threshold.set_keys(list);
+
+        let mut key = proto::BasicTypes::Key::new();
+        key.set_thresholdKey(threshold);
+
+        let key: Key = key.try_into()?;
+
+        assert_eq!(
+            key,
+            Key::Threshold {
+                threshold: 1,
+                keys: vec![Key::Ed25519(public), Key::Contract(contract)],
+            }
+        );
+
+        Ok(())
+    }
 }
@@ -1,4 +1,4 @@
-use crate::Status;
+use crate::{Status, TransactionId};
 use failure_derive::Fail;
 
 #[derive(Debug, Fail)]
@@ -9,6 +9,43 @@ pub enum ErrorKind {
     #[fail(display = "expected string of the format: {:?}", _0)]
     Parse(&'static str),
 
-    #[fail(display = "pre-check failed with status: {:?}", _0)]
-    PreCheck(Status),
+    /// The node rejected the transaction before submitting it to the network.
+    #[fail(
+        display = "transaction {} failed pre-check with status: {}",
+        transaction_id, status
+    )]
+    PreCheck {
+        status: Status,
+        transaction_id: TransactionId,
+    },
+
+    /// The transaction reached consensus, but failed.
+    #[fail(display = "transaction {} failed with status: {}", transaction_id, status)]
+    ReceiptStatus {
+        status: Status,
+        transaction_id: TransactionId,
+    },
+
+    /// The node rejected the query. Paid queries have the ID of their payment transaction.
+    #[fail(display = "query failed pre-check with status: {}", status)]
+    QueryStatus {
+        status: Status,
+        payment_transaction_id: Option<TransactionId>,
+    },
+
+    #[fail(display = "query cost of {} tinybars exceeds the maximum of {}", cost, max)]
+    MaxQueryPaymentExceeded { cost: u64, max: u64 },
+}
+
+impl ErrorKind {
+    /// The status of the network response that caused this error, if any.
+    pub fn status(&self) -> Option<Status> {
+        match self {
+            ErrorKind::PreCheck { status, .. }
+            | ErrorKind::ReceiptStatus { status, .. }
+            | ErrorKind::QueryStatus { status, .. } => Some(*status),
+
+            _ => None,
+        }
+    }
 }
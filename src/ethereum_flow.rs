@@ -0,0 +1,128 @@
+use crate::{
+    contract_create_flow::upload_file,
+    rlp::Rlp,
+    transaction::TransactionEthereum,
+    Client, TransactionReceipt,
+};
+use failure::{format_err, Error};
+use std::mem::replace;
+
+// Ethereum transactions larger than this have their call data moved to a file
+const MAX_ETHEREUM_DATA_SIZE: usize = 5120;
+
+/// Submit a raw, signed Ethereum transaction of any size.
+///
+/// If the transaction is too large to send directly, its call data is uploaded to a file owned
+/// by the operator of the client and the transaction is sent with the call data removed and a
+/// reference to that file instead.
+pub struct EthereumFlow<'a> {
+    client: &'a Client,
+    ethereum_data: Vec<u8>,
+    max_gas_allowance: i64,
+}
+
+impl<'a> EthereumFlow<'a> {
+    pub fn new(client: &'a Client, ethereum_data: Vec<u8>) -> Self {
+        Self {
+            client,
+            ethereum_data,
+            max_gas_allowance: 0,
+        }
+    }
+
+    /// The maximum amount, in tinybars, that the operator is willing to pay for gas the
+    /// Ethereum signer did not authorize.
+    #[inline]
+    pub fn max_gas_allowance(&mut self, allowance: i64) -> &mut Self {
+        self.max_gas_allowance = allowance;
+        self
+    }
+
+    /// Run every step of the flow and return the receipt of the Ethereum transaction.
+    pub async fn execute_async(&self) -> Result<TransactionReceipt, Error> {
+        let mut tx = if self.ethereum_data.len() > MAX_ETHEREUM_DATA_SIZE {
+            let (ethereum_data, call_data) = split_call_data(&self.ethereum_data)?;
+
+            // The network expects call data stored in a file to be hex encoded
+            let file = upload_file(self.client, hex::encode(&call_data).as_bytes()).await?;
+
+            let mut tx = TransactionEthereum::new(self.client, ethereum_data);
+            tx.call_data(file);
+            tx
+        } else {
+            TransactionEthereum::new(self.client, self.ethereum_data.clone())
+        };
+
+        tx.max_gas_allowance(self.max_gas_allowance)
+            .execute_async()
+            .await?
+            .get_receipt_async(self.client)
+            .await
+    }
+
+    pub fn execute(&self) -> Result<TransactionReceipt, Error> {
+        crate::RUNTIME.lock().block_on(self.execute_async())
+    }
+}
+
+// Remove the call data from an RLP encoded Ethereum transaction, returning the transaction
+// without it and the call data
+fn split_call_data(data: &[u8]) -> Result<(Vec<u8>, Vec<u8>), Error> {
+    // Typed transactions (EIP-2718) are prefixed with their type; legacy transactions are
+    // a bare RLP list
+    let (tx_type, payload, call_data_index) = match data.first() {
+        Some(0x01) => (Some(0x01), &data[1..], 6),
+        Some(0x02) => (Some(0x02), &data[1..], 7),
+        _ => (None, data, 5),
+    };
+
+    let mut items = match Rlp::decode(payload)? {
+        Rlp::List(items) => items,
+        Rlp::Bytes(_) => Err(format_err!("ethereum data is not an RLP list"))?,
+    };
+
+    let call_data = match items.get_mut(call_data_index) {
+        Some(Rlp::Bytes(call_data)) => replace(call_data, Vec::new()),
+        _ => Err(format_err!("ethereum data does not contain call data"))?,
+    };
+
+    let mut ethereum_data: Vec<u8> = tx_type.into_iter().collect();
+    ethereum_data.extend(Rlp::List(items).encode());
+
+    Ok((ethereum_data, call_data))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    // A transaction of `count` single byte fields with `call_data` at `index`
+    fn transaction(count: usize, index: usize, call_data: &[u8]) -> Vec<Rlp> {
+        let mut items: Vec<Rlp> = (0..count).map(|i| Rlp::Bytes(vec![i as u8 + 1])).collect();
+        items[index] = Rlp::Bytes(call_data.to_vec());
+        items
+    }
+
+    #[test]
+    fn test_split_call_data() -> Result<(), Error> {
+        let call_data = vec![0xab; 6000];
+
+        // legacy: [nonce, gasPrice, gasLimit, to, value, data, v, r, s]
+        let legacy = Rlp::List(transaction(9, 5, &call_data)).encode();
+
+        let (stripped, split) = split_call_data(&legacy)?;
+        assert_eq!(split, call_data);
+        assert_eq!(Rlp::decode(&stripped)?, Rlp::List(transaction(9, 5, &[])));
+
+        // EIP-1559: [chainId, nonce, maxPriorityFee, maxFee, gasLimit, to, value, data, ...]
+        let mut eip1559 = vec![0x02];
+        eip1559.extend(Rlp::List(transaction(12, 7, &call_data)).encode());
+
+        let (stripped, split) = split_call_data(&eip1559)?;
+        assert_eq!(split, call_data);
+        assert_eq!(stripped[0], 0x02);
+        assert_eq!(Rlp::decode(&stripped[1..])?, Rlp::List(transaction(12, 7, &[])));
+
+        Ok(())
+    }
+}
@@ -0,0 +1,167 @@
+use crate::proto::{self, ToProto};
+use chrono::{DateTime, Utc};
+use failure::{format_err, Error};
+use try_from::TryFrom;
+
+/// The exchange rate between hbars and US cents, as stored in file `0:0:112`.
+#[derive(Debug, Clone, PartialEq)]
+pub struct ExchangeRate {
+    /// Denominator of the rate; `hbar_equivalent` hbars are worth `cent_equivalent` cents.
+    pub hbar_equivalent: i32,
+    /// Numerator of the rate.
+    pub cent_equivalent: i32,
+    /// The time after which this rate is no longer in effect.
+    pub expiration_time: DateTime<Utc>,
+}
+
+impl ExchangeRate {
+    /// Convert an amount in tinycents to tinybars.
+    ///
+    /// Fails if the rate has no cent equivalent or the amount is too large to convert.
+    pub fn to_tinybars(&self, tinycents: i64) -> Result<i64, Error> {
+        convert(tinycents, self.hbar_equivalent, self.cent_equivalent)
+    }
+
+    /// Convert an amount in tinybars to tinycents.
+    ///
+    /// Fails if the rate has no hbar equivalent or the amount is too large to convert.
+    pub fn to_tinycents(&self, tinybars: i64) -> Result<i64, Error> {
+        convert(tinybars, self.cent_equivalent, self.hbar_equivalent)
+    }
+
+    /// The price of one hbar in US cents.
+    pub fn cents_per_hbar(&self) -> f64 {
+        f64::from(self.cent_equivalent) / f64::from(self.hbar_equivalent)
+    }
+}
+
+fn convert(amount: i64, numerator: i32, denominator: i32) -> Result<i64, Error> {
+    amount
+        .checked_mul(i64::from(numerator))
+        .and_then(|product| product.checked_div(i64::from(denominator)))
+        .ok_or_else(|| {
+            format_err!(
+                "cannot convert {} at an exchange rate of {}/{}",
+                amount,
+                numerator,
+                denominator
+            )
+        })
+}
+
+impl From<proto::ExchangeRate::ExchangeRate> for ExchangeRate {
+    fn from(mut rate: proto::ExchangeRate::ExchangeRate) -> Self {
+        Self {
+            hbar_equivalent: rate.get_hbarEquiv(),
+            cent_equivalent: rate.get_centEquiv(),
+            expiration_time: rate.take_expirationTime().into(),
+        }
+    }
+}
+
+impl ToProto<proto::ExchangeRate::ExchangeRate> for ExchangeRate {
+    fn to_proto(&self) -> Result<proto::ExchangeRate::ExchangeRate, Error> {
+        let mut rate = proto::ExchangeRate::ExchangeRate::new();
+        rate.set_hbarEquiv(self.hbar_equivalent);
+        rate.set_centEquiv(self.cent_equivalent);
+        rate.set_expirationTime(self.expiration_time.to_proto()?);
+
+        Ok(rate)
+    }
+}
+
+/// The current and next exchange rates of the network.
+#[derive(Debug, Clone, PartialEq)]
+pub struct ExchangeRates {
+    pub current: ExchangeRate,
+    pub next: ExchangeRate,
+}
+
+impl From<proto::ExchangeRate::ExchangeRateSet> for ExchangeRates {
+    fn from(mut rates: proto::ExchangeRate::ExchangeRateSet) -> Self {
+        Self {
+            current: rates.take_currentRate().into(),
+            next: rates.take_nextRate().into(),
+        }
+    }
+}
+
+impl ToProto<proto::ExchangeRate::ExchangeRateSet> for ExchangeRates {
+    fn to_proto(&self) -> Result<proto::ExchangeRate::ExchangeRateSet, Error> {
+        let mut rates = proto::ExchangeRate::ExchangeRateSet::new();
+        rates.set_currentRate(self.current.to_proto()?);
+        rates.set_nextRate(self.next.to_proto()?);
+
+        Ok(rates)
+    }
+}
+
+impl TryFrom<Vec<u8>> for ExchangeRates {
+    type Err = Error;
+
+    fn try_from(bytes: Vec<u8>) -> Result<Self, Error> {
+        let rates: proto::ExchangeRate::ExchangeRateSet = protobuf::parse_from_bytes(&bytes)?;
+
+        Ok(rates.into())
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::{ExchangeRate, ExchangeRates};
+    use crate::proto;
+    use chrono::{TimeZone, Utc};
+    use failure::Error;
+    use protobuf::Message;
+    use try_from::TryInto;
+
+    #[test]
+    fn test_parse() -> Result<(), Error> {
+        let mut current = proto::ExchangeRate::ExchangeRate::new();
+        current.set_hbarEquiv(30_000);
+        current.set_centEquiv(120_000);
+        current.mut_expirationTime().set_seconds(1_568_592_000);
+
+        let mut next = current.clone();
+        next.set_centEquiv(150_000);
+        next.mut_expirationTime().set_seconds(1_568_595_600);
+
+        let mut set = proto::ExchangeRate::ExchangeRateSet::new();
+        set.set_currentRate(current);
+        set.set_nextRate(next);
+
+        let rates: ExchangeRates = set.write_to_bytes()?.try_into()?;
+
+        assert_eq!(rates.current.hbar_equivalent, 30_000);
+        assert_eq!(rates.current.cent_equivalent, 120_000);
+        assert_eq!(rates.current.expiration_time.timestamp(), 1_568_592_000);
+        assert_eq!(rates.next.cent_equivalent, 150_000);
+        assert_eq!(rates.next.expiration_time.timestamp(), 1_568_595_600);
+
+        // 1 hbar is worth 4 cents at the current rate
+        assert!((rates.current.cents_per_hbar() - 4.0).abs() < std::f64::EPSILON);
+        assert_eq!(rates.current.to_tinycents(100_000_000)?, 400_000_000);
+        assert_eq!(rates.current.to_tinybars(400_000_000)?, 100_000_000);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_invalid_conversions() {
+        let rate = ExchangeRate {
+            hbar_equivalent: 30_000,
+            cent_equivalent: 0,
+            expiration_time: Utc.timestamp(1_568_592_000, 0),
+        };
+
+        assert!(rate.to_tinybars(400_000_000).is_err());
+        assert_eq!(rate.to_tinycents(100_000_000).unwrap(), 0);
+
+        let rate = ExchangeRate {
+            cent_equivalent: 120_000,
+            ..rate
+        };
+
+        assert!(rate.to_tinycents(std::i64::MAX).is_err());
+    }
+}
@@ -0,0 +1,407 @@
+use crate::proto::{self, TransactionBody::TransactionBody_oneof_data};
+use chrono::{DateTime, Utc};
+use failure::Error;
+use try_from::TryFrom;
+
+// The prices of a fee schedule are in thousandths of a tinycent
+const FEE_DIVISOR: i64 = 1000;
+
+/// An operation that the network charges a fee for.
+#[derive(Debug, Copy, Clone, PartialEq)]
+pub enum HederaFunctionality {
+    None,
+    CryptoTransfer,
+    CryptoUpdate,
+    CryptoDelete,
+    CryptoAddClaim,
+    CryptoDeleteClaim,
+    ContractCall,
+    ContractCreate,
+    ContractUpdate,
+    FileCreate,
+    FileAppend,
+    FileUpdate,
+    FileDelete,
+    CryptoGetAccountBalance,
+    CryptoGetAccountRecords,
+    CryptoGetInfo,
+    ContractCallLocal,
+    ContractGetInfo,
+    ContractGetBytecode,
+    GetBySolidityId,
+    GetByKey,
+    CryptoGetClaim,
+    CryptoGetStakers,
+    FileGetContents,
+    FileGetInfo,
+    TransactionGetRecord,
+    ContractGetRecords,
+    CryptoCreate,
+    SystemDelete,
+    SystemUndelete,
+    ContractDelete,
+    Freeze,
+    CreateTransactionRecord,
+    CryptoAccountAutoRenew,
+    ContractAutoRenew,
+    GetVersion,
+    TransactionGetReceipt,
+    ScheduleCreate,
+    ScheduleDelete,
+    ScheduleSign,
+    ScheduleGetInfo,
+    EthereumTransaction,
+    UtilPrng,
+    NodeCreate,
+    NodeUpdate,
+    NodeDelete,
+    AtomicBatch,
+}
+
+impl HederaFunctionality {
+    pub(crate) fn of(data: &TransactionBody_oneof_data) -> Self {
+        use self::TransactionBody_oneof_data::*;
+
+        match data {
+            contractCall(_) => HederaFunctionality::ContractCall,
+            contractCreateInstance(_) => HederaFunctionality::ContractCreate,
+            contractUpdateInstance(_) => HederaFunctionality::ContractUpdate,
+            contractDeleteInstance(_) => HederaFunctionality::ContractDelete,
+            cryptoAddClaim(_) => HederaFunctionality::CryptoAddClaim,
+            cryptoCreateAccount(_) => HederaFunctionality::CryptoCreate,
+            cryptoDelete(_) => HederaFunctionality::CryptoDelete,
+            cryptoDeleteClaim(_) => HederaFunctionality::CryptoDeleteClaim,
+            cryptoTransfer(_) => HederaFunctionality::CryptoTransfer,
+            cryptoUpdateAccount(_) => HederaFunctionality::CryptoUpdate,
+            fileAppend(_) => HederaFunctionality::FileAppend,
+            fileCreate(_) => HederaFunctionality::FileCreate,
+            fileDelete(_) => HederaFunctionality::FileDelete,
+            fileUpdate(_) => HederaFunctionality::FileUpdate,
+            systemDelete(_) => HederaFunctionality::SystemDelete,
+            systemUndelete(_) => HederaFunctionality::SystemUndelete,
+            freeze(_) => HederaFunctionality::Freeze,
+            scheduleCreate(_) => HederaFunctionality::ScheduleCreate,
+            scheduleDelete(_) => HederaFunctionality::ScheduleDelete,
+            scheduleSign(_) => HederaFunctionality::ScheduleSign,
+            ethereumTransaction(_) => HederaFunctionality::EthereumTransaction,
+            util_prng(_) => HederaFunctionality::UtilPrng,
+            nodeCreate(_) => HederaFunctionality::NodeCreate,
+            nodeUpdate(_) => HederaFunctionality::NodeUpdate,
+            nodeDelete(_) => HederaFunctionality::NodeDelete,
+            atomic_batch(_) => HederaFunctionality::AtomicBatch,
+        }
+    }
+}
+
+impl From<proto::BasicTypes::HederaFunctionality> for HederaFunctionality {
+    fn from(functionality: proto::BasicTypes::HederaFunctionality) -> Self {
+        use self::proto::BasicTypes::HederaFunctionality::*;
+
+        match functionality {
+            NONE => HederaFunctionality::None,
+            CryptoTransfer => HederaFunctionality::CryptoTransfer,
+            CryptoUpdate => HederaFunctionality::CryptoUpdate,
+            CryptoDelete => HederaFunctionality::CryptoDelete,
+            CryptoAddClaim => HederaFunctionality::CryptoAddClaim,
+            CryptoDeleteClaim => HederaFunctionality::CryptoDeleteClaim,
+            ContractCall => HederaFunctionality::ContractCall,
+            ContractCreate => HederaFunctionality::ContractCreate,
+            ContractUpdate => HederaFunctionality::ContractUpdate,
+            FileCreate => HederaFunctionality::FileCreate,
+            FileAppend => HederaFunctionality::FileAppend,
+            FileUpdate => HederaFunctionality::FileUpdate,
+            FileDelete => HederaFunctionality::FileDelete,
+            CryptoGetAccountBalance => HederaFunctionality::CryptoGetAccountBalance,
+            CryptoGetAccountRecords => HederaFunctionality::CryptoGetAccountRecords,
+            CryptoGetInfo => HederaFunctionality::CryptoGetInfo,
+            ContractCallLocal => HederaFunctionality::ContractCallLocal,
+            ContractGetInfo => HederaFunctionality::ContractGetInfo,
+            ContractGetBytecode => HederaFunctionality::ContractGetBytecode,
+            GetBySolidityID => HederaFunctionality::GetBySolidityId,
+            GetByKey => HederaFunctionality::GetByKey,
+            CryptoGetClaim => HederaFunctionality::CryptoGetClaim,
+            CryptoGetStakers => HederaFunctionality::CryptoGetStakers,
+            FileGetContents => HederaFunctionality::FileGetContents,
+            FileGetInfo => HederaFunctionality::FileGetInfo,
+            TransactionGetRecord => HederaFunctionality::TransactionGetRecord,
+            ContractGetRecords => HederaFunctionality::ContractGetRecords,
+            CryptoCreate => HederaFunctionality::CryptoCreate,
+            SystemDelete => HederaFunctionality::SystemDelete,
+            SystemUndelete => HederaFunctionality::SystemUndelete,
+            ContractDelete => HederaFunctionality::ContractDelete,
+            Freeze => HederaFunctionality::Freeze,
+            CreateTransactionRecord => HederaFunctionality::CreateTransactionRecord,
+            CryptoAccountAutoRenew => HederaFunctionality::CryptoAccountAutoRenew,
+            ContractAutoRenew => HederaFunctionality::ContractAutoRenew,
+            getVersion => HederaFunctionality::GetVersion,
+            TransactionGetReceipt => HederaFunctionality::TransactionGetReceipt,
+            ScheduleCreate => HederaFunctionality::ScheduleCreate,
+            ScheduleDelete => HederaFunctionality::ScheduleDelete,
+            ScheduleSign => HederaFunctionality::ScheduleSign,
+            ScheduleGetInfo => HederaFunctionality::ScheduleGetInfo,
+            EthereumTransaction => HederaFunctionality::EthereumTransaction,
+            UtilPrng => HederaFunctionality::UtilPrng,
+            NodeCreate => HederaFunctionality::NodeCreate,
+            NodeUpdate => HederaFunctionality::NodeUpdate,
+            NodeDelete => HederaFunctionality::NodeDelete,
+            AtomicBatch => HederaFunctionality::AtomicBatch,
+        }
+    }
+}
+
+/// The price of each resource a transaction or query consumes.
+#[derive(Debug, Clone, Default, PartialEq)]
+pub struct FeeComponents {
+    pub min: i64,
+    pub max: i64,
+    pub constant: i64,
+    pub bytes_per_transaction: i64,
+    pub verifications_per_transaction: i64,
+    pub ram_byte_hours: i64,
+    pub storage_byte_hours: i64,
+    pub gas: i64,
+    pub transaction_value: i64,
+    pub bytes_per_response: i64,
+    pub storage_bytes_per_response: i64,
+}
+
+impl FeeComponents {
+    /// The fee in tinycents for the given usage of each resource, bounded by `min` and `max`.
+    ///
+    /// The prices of the fee schedule are in thousandths of a tinycent.
+    pub fn fee(&self, usage: &FeeComponents) -> i64 {
+        // saturates rather than overflows, as the fee is capped at `max` anyway
+        let fee = [
+            (self.constant, usage.constant),
+            (self.bytes_per_transaction, usage.bytes_per_transaction),
+            (self.verifications_per_transaction, usage.verifications_per_transaction),
+            (self.ram_byte_hours, usage.ram_byte_hours),
+            (self.storage_byte_hours, usage.storage_byte_hours),
+            (self.gas, usage.gas),
+            (self.transaction_value, usage.transaction_value),
+            (self.bytes_per_response, usage.bytes_per_response),
+            (self.storage_bytes_per_response, usage.storage_bytes_per_response),
+        ]
+        .iter()
+        .fold(0i64, |fee, (price, used)| fee.saturating_add(price.saturating_mul(*used)));
+
+        fee.max(self.min).min(self.max) / FEE_DIVISOR
+    }
+}
+
+impl From<proto::BasicTypes::FeeComponents> for FeeComponents {
+    fn from(components: proto::BasicTypes::FeeComponents) -> Self {
+        Self {
+            min: components.get_min(),
+            max: components.get_max(),
+            constant: components.get_constant(),
+            bytes_per_transaction: components.get_bpt(),
+            verifications_per_transaction: components.get_vpt(),
+            ram_byte_hours: components.get_rbh(),
+            storage_byte_hours: components.get_sbh(),
+            gas: components.get_gas(),
+            transaction_value: components.get_tv(),
+            bytes_per_response: components.get_bpr(),
+            storage_bytes_per_response: components.get_sbpr(),
+        }
+    }
+}
+
+/// The fee components charged by the node, the network, and the service.
+#[derive(Debug, Clone, Default, PartialEq)]
+pub struct FeeData {
+    pub node: FeeComponents,
+    pub network: FeeComponents,
+    pub service: FeeComponents,
+}
+
+impl FeeData {
+    /// The total fee in tinycents for the given usage of the node, the network, and the
+    /// service.
+    pub fn fee(&self, usage: &FeeData) -> i64 {
+        self.node
+            .fee(&usage.node)
+            .saturating_add(self.network.fee(&usage.network))
+            .saturating_add(self.service.fee(&usage.service))
+    }
+}
+
+impl From<proto::BasicTypes::FeeData> for FeeData {
+    fn from(mut data: proto::BasicTypes::FeeData) -> Self {
+        Self {
+            node: data.take_nodedata().into(),
+            network: data.take_networkdata().into(),
+            service: data.take_servicedata().into(),
+        }
+    }
+}
+
+#[derive(Debug, Clone, PartialEq)]
+pub struct TransactionFeeSchedule {
+    pub functionality: HederaFunctionality,
+    pub fee_data: FeeData,
+}
+
+impl From<proto::BasicTypes::TransactionFeeSchedule> for TransactionFeeSchedule {
+    fn from(mut schedule: proto::BasicTypes::TransactionFeeSchedule) -> Self {
+        Self {
+            functionality: schedule.get_hederaFunctionality().into(),
+            fee_data: schedule.take_feeData().into(),
+        }
+    }
+}
+
+#[derive(Debug, Clone, PartialEq)]
+pub struct FeeSchedule {
+    pub transaction_fee_schedules: Vec<TransactionFeeSchedule>,
+    pub expiration_time: DateTime<Utc>,
+}
+
+impl FeeSchedule {
+    /// Find the fee data for the given functionality, if this schedule prices it.
+    pub fn fee_data(&self, functionality: HederaFunctionality) -> Option<&FeeData> {
+        self.transaction_fee_schedules
+            .iter()
+            .find(|schedule| schedule.functionality == functionality)
+            .map(|schedule| &schedule.fee_data)
+    }
+}
+
+impl From<proto::BasicTypes::FeeSchedule> for FeeSchedule {
+    fn from(mut schedule: proto::BasicTypes::FeeSchedule) -> Self {
+        Self {
+            transaction_fee_schedules: schedule
+                .take_transactionFeeSchedule()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            expiration_time: schedule.take_expiryTime().into(),
+        }
+    }
+}
+
+/// The current and next fee schedules of the network, as stored in file `0:0:111`.
+#[derive(Debug, Clone, PartialEq)]
+pub struct FeeSchedules {
+    pub current: Option<FeeSchedule>,
+    pub next: Option<FeeSchedule>,
+}
+
+impl From<proto::BasicTypes::CurrentAndNextFeeSchedule> for FeeSchedules {
+    fn from(mut schedules: proto::BasicTypes::CurrentAndNextFeeSchedule) -> Self {
+        Self {
+            current: if schedules.has_currentFeeSchedule() {
+                Some(schedules.take_currentFeeSchedule().into())
+            } else {
+                None
+            },
+            next: if schedules.has_nextFeeSchedule() {
+                Some(schedules.take_nextFeeSchedule().into())
+            } else {
+                None
+            },
+        }
+    }
+}
+
+impl TryFrom<Vec<u8>> for FeeSchedules {
+    type Err = Error;
+
+    fn try_from(bytes: Vec<u8>) -> Result<Self, Error> {
+        let schedules: proto::BasicTypes::CurrentAndNextFeeSchedule =
+            protobuf::parse_from_bytes(&bytes)?;
+
+        Ok(schedules.into())
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::{FeeComponents, FeeData};
+
+    fn prices() -> FeeComponents {
+        FeeComponents {
+            min: 0,
+            max: 1_000_000_000_000,
+            constant: 1_000_000,
+            bytes_per_transaction: 2_000,
+            verifications_per_transaction: 500_000,
+            ..FeeComponents::default()
+        }
+    }
+
+    #[test]
+    fn test_components_fee() {
+        let usage = FeeComponents {
+            constant: 1,
+            bytes_per_transaction: 200,
+            verifications_per_transaction: 2,
+            ..FeeComponents::default()
+        };
+
+        // (1_000_000 + 200 * 2_000 + 2 * 500_000) / 1000
+        assert_eq!(prices().fee(&usage), 2_400);
+    }
+
+    #[test]
+    fn test_components_fee_bounds() {
+        let usage = FeeComponents {
+            constant: 1,
+            ..FeeComponents::default()
+        };
+
+        let min = FeeComponents {
+            min: 5_000_000,
+            ..prices()
+        };
+
+        let max = FeeComponents {
+            max: 500_000,
+            ..prices()
+        };
+
+        assert_eq!(min.fee(&usage), 5_000);
+        assert_eq!(max.fee(&usage), 500);
+
+        let huge = FeeComponents {
+            gas: std::i64::MAX,
+            ..FeeComponents::default()
+        };
+
+        let gas = FeeComponents {
+            gas: 1_000,
+            ..prices()
+        };
+
+        // saturates at the maximum instead of overflowing
+        assert_eq!(gas.fee(&huge), 1_000_000_000);
+    }
+
+    #[test]
+    fn test_data_fee() {
+        let usage = FeeComponents {
+            constant: 1,
+            ..FeeComponents::default()
+        };
+
+        let data = FeeData {
+            node: prices(),
+            network: FeeComponents {
+                constant: 2_000_000,
+                ..prices()
+            },
+            service: FeeComponents {
+                constant: 3_000_000,
+                ..prices()
+            },
+        };
+
+        let fee = data.fee(&FeeData {
+            node: usage.clone(),
+            network: usage.clone(),
+            service: usage,
+        });
+
+        assert_eq!(fee, 1_000 + 2_000 + 3_000);
+    }
+}
@@ -1,9 +1,14 @@
 use crate::{
     id::ContractId,
-    proto::{self},
+    proto::{self, ToProto},
 };
 use failure::Error;
 use hex;
+use protobuf::{
+    well_known_types::{BytesValue, Int64Value},
+    RepeatedField,
+};
+use sha3::{Digest, Keccak256};
 
 #[derive(Debug, Clone)]
 pub struct ContractLogInfo {
@@ -13,6 +18,28 @@ pub struct ContractLogInfo {
     pub data: Vec<u8>,
 }
 
+impl ContractLogInfo {
+    /// Check if this log was emitted by the event with the given signature,
+    /// e.g. `Transfer(address,address,uint256)`.
+    pub fn is_event(&self, signature: &str) -> bool {
+        let mut hasher = Keccak256::default();
+        hasher.input(signature.as_bytes());
+
+        self.topic.first().map_or(false, |t| t[..] == hasher.result()[..])
+    }
+
+    /// Get the indexed event parameter at `index`; the first indexed parameter is `0`.
+    pub fn get_indexed(&self, index: usize) -> Option<&[u8]> {
+        self.topic.get(index + 1).map(|t| &t[..])
+    }
+
+    /// Get the non-indexed event parameters for decoding with the typed getters
+    /// of `ContractFunctionResult`.
+    pub fn values(&self) -> ContractFunctionResult {
+        ContractFunctionResult::with_call_result(self.contract_id, self.data.clone())
+    }
+}
+
 impl From<proto::ContractCallLocal::ContractLoginfo> for ContractLogInfo {
     fn from(mut log: proto::ContractCallLocal::ContractLoginfo) -> Self {
         Self {
@@ -24,6 +51,18 @@ impl From<proto::ContractCallLocal::ContractLoginfo> for ContractLogInfo {
     }
 }
 
+impl ToProto<proto::ContractCallLocal::ContractLoginfo> for ContractLogInfo {
+    fn to_proto(&self) -> Result<proto::ContractCallLocal::ContractLoginfo, Error> {
+        let mut log = proto::ContractCallLocal::ContractLoginfo::new();
+        log.set_contractID(self.contract_id.to_proto()?);
+        log.set_bloom(self.bloom.clone());
+        log.set_topic(RepeatedField::from_vec(self.topic.clone()));
+        log.set_data(self.data.clone());
+
+        Ok(log)
+    }
+}
+
 #[derive(Debug, Clone)]
 pub struct ContractFunctionResult {
     pub contract_id: ContractId,
@@ -32,9 +71,67 @@ pub struct ContractFunctionResult {
     pub bloom: Vec<u8>,
     pub gas_used: u64,
     pub log_info: Vec<ContractLogInfo>,
+    /// The EVM address of the new contract, if this is the result of a contract creation.
+    pub evm_address: Option<Vec<u8>>,
+    /// The new nonce of each contract account whose nonce was changed by the call.
+    pub contract_nonces: Vec<ContractNonceInfo>,
+    /// The nonce of the signer account after the call, for Ethereum transactions.
+    pub signer_nonce: Option<i64>,
+}
+
+/// The nonce of a contract account; it is incremented when the contract creates another contract.
+#[derive(Debug, Clone)]
+pub struct ContractNonceInfo {
+    pub contract_id: ContractId,
+    pub nonce: i64,
+}
+
+impl From<proto::ContractCallLocal::ContractNonceInfo> for ContractNonceInfo {
+    fn from(mut info: proto::ContractCallLocal::ContractNonceInfo) -> Self {
+        Self {
+            contract_id: info.take_contract_id().into(),
+            nonce: info.get_nonce(),
+        }
+    }
+}
+
+impl ToProto<proto::ContractCallLocal::ContractNonceInfo> for ContractNonceInfo {
+    fn to_proto(&self) -> Result<proto::ContractCallLocal::ContractNonceInfo, Error> {
+        let mut info = proto::ContractCallLocal::ContractNonceInfo::new();
+        info.set_contract_id(self.contract_id.to_proto()?);
+        info.set_nonce(self.nonce);
+
+        Ok(info)
+    }
 }
 
 impl ContractFunctionResult {
+    fn with_call_result(contract_id: ContractId, contract_call_result: Vec<u8>) -> Self {
+        Self {
+            contract_id,
+            contract_call_result,
+            error_message: String::new(),
+            bloom: Vec::new(),
+            gas_used: 0,
+            log_info: Vec::new(),
+            evm_address: None,
+            contract_nonces: Vec::new(),
+            signer_nonce: None,
+        }
+    }
+
+    // Offset of the dynamic value at `val_index`; the head of a dynamic value holds the offset
+    // to its contents
+    fn get_offset(&self, val_index: usize) -> usize {
+        self.get_word_as_u64(val_index * 32) as usize
+    }
+
+    fn get_word_as_u64(&self, offset: usize) -> u64 {
+        let mut bytes: [u8; 8] = Default::default();
+        bytes.copy_from_slice(&self.contract_call_result[offset + 24..offset + 32]);
+        u64::from_be_bytes(bytes)
+    }
+
     fn get_byte_buffer(&self, offset: usize) -> u8 {
         self.contract_call_result[offset]
     }
@@ -65,8 +162,8 @@ impl ContractFunctionResult {
     }
 
     pub fn get_bytes(&self, val_index: usize) -> Vec<u8> {
-        let offset = self.get_int(val_index) as usize;
-        let l = self.get_int_value_at(offset as usize) as usize;
+        let offset = self.get_offset(val_index);
+        let l = self.get_word_as_u64(offset) as usize;
         self.contract_call_result[offset + 32..offset + 32 + l].to_vec()
     }
 
@@ -98,6 +195,70 @@ impl ContractFunctionResult {
         self.contract_call_result[offset + 12..offset + 32].to_vec()
     }
 
+    /// Get a `uint256` as its 32 big-endian bytes.
+    pub fn get_uint256(&self, val_index: usize) -> Vec<u8> {
+        self.get_int_256(val_index)
+    }
+
+    /// Get an `int256` as its 32 big-endian, two's complement bytes.
+    pub fn get_int256(&self, val_index: usize) -> Vec<u8> {
+        self.get_int_256(val_index)
+    }
+
+    pub fn get_uint64(&self, val_index: usize) -> u64 {
+        self.get_word_as_u64(val_index * 32)
+    }
+
+    pub fn get_int64(&self, val_index: usize) -> i64 {
+        self.get_word_as_u64(val_index * 32) as i64
+    }
+
+    pub fn get_bytes32(&self, val_index: usize) -> Vec<u8> {
+        self.get_int_256(val_index)
+    }
+
+    /// Get a `uint256[]` as the 32 big-endian bytes of each element.
+    pub fn get_uint256_array(&self, val_index: usize) -> Vec<Vec<u8>> {
+        let offset = self.get_offset(val_index);
+        let ln = self.get_word_as_u64(offset) as usize;
+        let start = offset + 32;
+
+        (0..ln)
+            .map(|i| self.contract_call_result[start + i * 32..start + (i + 1) * 32].to_vec())
+            .collect()
+    }
+
+    pub fn get_bytes32_array(&self, val_index: usize) -> Vec<Vec<u8>> {
+        self.get_uint256_array(val_index)
+    }
+
+    pub fn get_string_array(&self, val_index: usize) -> Result<Vec<String>, Error> {
+        let offset = self.get_offset(val_index);
+        let elements = self.get_tuple_at(offset + 32);
+        let ln = self.get_word_as_u64(offset) as usize;
+
+        (0..ln).map(|i| elements.get_string(i)).collect()
+    }
+
+    /// Get a tuple (struct) containing dynamic values; its members can be read with the
+    /// typed getters of the returned result.
+    ///
+    /// Tuples of only static values are encoded in-place, so their members are read
+    /// directly at consecutive indices instead.
+    pub fn get_tuple(&self, val_index: usize) -> ContractFunctionResult {
+        self.get_tuple_at(self.get_offset(val_index))
+    }
+
+    fn get_tuple_at(&self, offset: usize) -> ContractFunctionResult {
+        Self::with_call_result(self.contract_id, self.contract_call_result[offset..].to_vec())
+    }
+
+    /// The logs of the call that were emitted by the event with the given signature,
+    /// e.g. `Transfer(address,address,uint256)`.
+    pub fn logs_of<'a>(&'a self, signature: &'a str) -> impl Iterator<Item = &'a ContractLogInfo> {
+        self.log_info.iter().filter(move |log| log.is_event(signature))
+    }
+
     pub fn get_address_array(&self, val_index: usize) -> Vec<String> {
         let offset = (val_index * 32) + 32;
         let start = offset + 32;
@@ -121,6 +282,94 @@ impl From<proto::ContractCallLocal::ContractFunctionResult> for ContractFunction
             bloom: result.take_bloom(),
             gas_used: result.get_gasUsed(),
             log_info: result.take_logInfo().into_iter().map(Into::into).collect(),
+            evm_address: if result.has_evm_address() {
+                Some(result.take_evm_address().take_value())
+            } else {
+                None
+            },
+            contract_nonces: result
+                .take_contract_nonces()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            signer_nonce: if result.has_signer_nonce() {
+                Some(result.get_signer_nonce().get_value())
+            } else {
+                None
+            },
+        }
+    }
+}
+
+impl ToProto<proto::ContractCallLocal::ContractFunctionResult> for ContractFunctionResult {
+    fn to_proto(&self) -> Result<proto::ContractCallLocal::ContractFunctionResult, Error> {
+        let mut result = proto::ContractCallLocal::ContractFunctionResult::new();
+        result.set_contractID(self.contract_id.to_proto()?);
+        result.set_contractCallResult(self.contract_call_result.clone());
+        result.set_errorMessage(self.error_message.clone());
+        result.set_bloom(self.bloom.clone());
+        result.set_gasUsed(self.gas_used);
+
+        let log_info: Result<Vec<_>, Error> = self.log_info.iter().map(ToProto::to_proto).collect();
+        result.set_logInfo(RepeatedField::from_vec(log_info?));
+
+        if let Some(address) = &self.evm_address {
+            let mut value = BytesValue::new();
+            value.set_value(address.clone());
+            result.set_evm_address(value);
+        }
+
+        let contract_nonces: Result<Vec<_>, Error> =
+            self.contract_nonces.iter().map(ToProto::to_proto).collect();
+        result.set_contract_nonces(RepeatedField::from_vec(contract_nonces?));
+
+        if let Some(nonce) = self.signer_nonce {
+            let mut value = Int64Value::new();
+            value.set_value(nonce);
+            result.set_signer_nonce(value);
         }
+
+        Ok(result)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use crate::call_params::CallParams;
+
+    fn result(params: &CallParams) -> ContractFunctionResult {
+        ContractFunctionResult::with_call_result(ContractId::new(0, 0, 0), params.assemble())
+    }
+
+    #[test]
+    fn test_decode() -> Result<(), Error> {
+        let mut tuple = CallParams::new(None);
+        tuple.add_string("nested".to_string());
+        tuple.add_uint(7, 64);
+
+        let mut cp = CallParams::new(None);
+        cp.add_uint(0x1122_3344, 256);
+        cp.add_string("Hello, world!".to_string());
+        cp.add_fixed_bytes(vec![0xab; 32], 32);
+        cp.add_string_array(vec!["lorem".to_string(), "ipsum".to_string()]);
+        cp.add_uint_array(vec![1, 2, 3], 256);
+        cp.add_tuple(&tuple);
+
+        let result = result(&cp);
+
+        assert_eq!(result.get_uint64(0), 0x1122_3344);
+        assert_eq!(hex::encode(&result.get_uint256(0)[28..]), "11223344");
+        assert_eq!(result.get_string(1)?, "Hello, world!");
+        assert_eq!(result.get_bytes32(2), vec![0xab; 32]);
+        assert_eq!(result.get_string_array(3)?, vec!["lorem", "ipsum"]);
+        assert_eq!(result.get_uint256_array(4).len(), 3);
+        assert_eq!(result.get_uint256_array(4)[2][31], 3);
+
+        let tuple = result.get_tuple(5);
+        assert_eq!(tuple.get_string(0)?, "nested");
+        assert_eq!(tuple.get_uint64(1), 7);
+
+        Ok(())
     }
-}
\ No newline at end of file
+}
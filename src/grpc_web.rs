@@ -0,0 +1,173 @@
+use failure::{format_err, Error};
+use protobuf::Message;
+
+// Frames with this bit set in their flags hold the trailers instead of a message
+const TRAILERS_FLAG: u8 = 0x80;
+
+/// A client for gRPC over HTTP/1.1, through a grpc-web proxy, for where HTTP/2 is blocked.
+pub(crate) struct GrpcWebClient {
+    http: reqwest::Client,
+    base_url: String,
+}
+
+impl GrpcWebClient {
+    pub(crate) fn new(base_url: impl Into<String>) -> Self {
+        Self {
+            http: reqwest::Client::new(),
+            base_url: base_url.into().trim_end_matches('/').to_owned(),
+        }
+    }
+
+    /// Call the unary method at `path`, e.g. `/proto.CryptoService/cryptoTransfer`.
+    pub(crate) async fn unary<Req: Message, Res: Message>(
+        &self,
+        path: &str,
+        request: &Req,
+    ) -> Result<Res, Error> {
+        let url = format!("{}{}", self.base_url, path);
+        let message = request.write_to_bytes()?;
+
+        let mut body = Vec::with_capacity(message.len() + 5);
+        body.push(0);
+        body.extend_from_slice(&(message.len() as u32).to_be_bytes());
+        body.extend_from_slice(&message);
+
+        log::trace!(target: "hedera::grpc_web", "post: {} ({} bytes)", url, body.len());
+
+        let response = self
+            .http
+            .post(&url)
+            .header(reqwest::header::CONTENT_TYPE, "application/grpc-web+proto")
+            .header(reqwest::header::ACCEPT, "application/grpc-web+proto")
+            .header("x-grpc-web", "1")
+            .body(body)
+            .send()
+            .await?;
+
+        let status = response.status();
+
+        if !status.is_success() {
+            return Err(format_err!("grpc-web request to {} failed with status {}", url, status));
+        }
+
+        // a response without a message has its status in the headers instead of trailers
+        let header_status = grpc_status(
+            response
+                .headers()
+                .get("grpc-status")
+                .and_then(|value| value.to_str().ok()),
+            response
+                .headers()
+                .get("grpc-message")
+                .and_then(|value| value.to_str().ok()),
+        );
+
+        let body = response.bytes().await?;
+        let (message, trailers) = parse_frames(&body)?;
+
+        header_status?;
+
+        if let Some(trailers) = trailers {
+            parse_trailers(&trailers)?;
+        }
+
+        let message = message.ok_or_else(|| format_err!("grpc-web response has no message"))?;
+
+        Ok(protobuf::parse_from_bytes(&message)?)
+    }
+}
+
+// Split a grpc-web body into its message, if any, and its trailers, if any
+fn parse_frames(mut body: &[u8]) -> Result<(Option<Vec<u8>>, Option<Vec<u8>>), Error> {
+    let mut message = None;
+    let mut trailers = None;
+
+    while !body.is_empty() {
+        if body.len() < 5 {
+            return Err(format_err!("truncated grpc-web frame header"));
+        }
+
+        let flags = body[0];
+        let len = u32::from_be_bytes([body[1], body[2], body[3], body[4]]) as usize;
+
+        if body.len() < 5 + len {
+            return Err(format_err!("truncated grpc-web frame of {} bytes", len));
+        }
+
+        let data = body[5..5 + len].to_vec();
+        body = &body[5 + len..];
+
+        if flags & TRAILERS_FLAG != 0 {
+            trailers = Some(data);
+        } else {
+            message = Some(data);
+        }
+    }
+
+    Ok((message, trailers))
+}
+
+// Trailers are sent as HTTP/1 headers, e.g. `grpc-status: 0\r\ngrpc-message: \r\n`
+fn parse_trailers(trailers: &[u8]) -> Result<(), Error> {
+    let trailers = String::from_utf8_lossy(trailers);
+
+    let mut status = None;
+    let mut message = None;
+
+    for line in trailers.split("\r\n") {
+        let mut parts = line.splitn(2, ':');
+
+        match (parts.next(), parts.next()) {
+            (Some(name), Some(value)) if name.eq_ignore_ascii_case("grpc-status") => {
+                status = Some(value.trim());
+            }
+
+            (Some(name), Some(value)) if name.eq_ignore_ascii_case("grpc-message") => {
+                message = Some(value.trim());
+            }
+
+            _ => {}
+        }
+    }
+
+    grpc_status(status, message)
+}
+
+fn grpc_status(status: Option<&str>, message: Option<&str>) -> Result<(), Error> {
+    match status {
+        None | Some("0") => Ok(()),
+        Some(status) => Err(format_err!(
+            "grpc-web call failed with status {}: {}",
+            status,
+            message.unwrap_or_default()
+        )),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::{parse_frames, parse_trailers};
+
+    #[test]
+    fn test_parse_frames() {
+        let mut body = vec![0, 0, 0, 0, 2, 8, 1];
+        body.extend_from_slice(&[0x80, 0, 0, 0, 16]);
+        body.extend_from_slice(b"grpc-status: 0\r\n");
+
+        let (message, trailers) = parse_frames(&body).unwrap();
+
+        assert_eq!(message, Some(vec![8, 1]));
+        assert!(parse_trailers(&trailers.unwrap()).is_ok());
+    }
+
+    #[test]
+    fn test_parse_failed_trailers() {
+        let error = parse_trailers(b"grpc-status: 14\r\ngrpc-message: unavailable\r\n")
+            .unwrap_err();
+
+        assert_eq!(
+            error.to_string(),
+            "grpc-web call failed with status 14: unavailable"
+        );
+    }
+}
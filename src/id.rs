@@ -81,3 +81,154 @@ define_id!(
     set_contractNum,
     get_contractNum
 );
+
+define_id!(token, TokenId, TokenID, set_tokenNum, get_tokenNum);
+
+define_id!(
+    schedule,
+    ScheduleId,
+    ScheduleID,
+    set_scheduleNum,
+    get_scheduleNum
+);
+
+define_id!(topic, TopicId, TopicID, set_topicNum, get_topicNum);
+
+/// The ID of a single NFT; a serial number of a non-fungible token.
+#[derive(Debug, PartialEq, Clone, Copy)]
+#[repr(C)]
+pub struct NftId {
+    pub token_id: TokenId,
+    pub serial: i64,
+}
+
+impl NftId {
+    pub fn new(token_id: TokenId, serial: i64) -> Self {
+        Self { token_id, serial }
+    }
+}
+
+impl std::fmt::Display for NftId {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "{}/{}", self.token_id, self.serial)
+    }
+}
+
+impl std::str::FromStr for NftId {
+    type Err = failure::Error;
+
+    fn from_str(s: &str) -> Result<Self, Self::Err> {
+        use crate::ErrorKind::Parse;
+
+        let index = s.find('/').ok_or_else(|| Parse("{token}/{serial}"))?;
+
+        Ok(Self::new(s[..index].parse()?, s[index + 1..].parse()?))
+    }
+}
+
+impl From<crate::proto::BasicTypes::NftID> for NftId {
+    fn from(mut pb: crate::proto::BasicTypes::NftID) -> Self {
+        Self {
+            token_id: pb.take_token_ID().into(),
+            serial: pb.get_serial_number(),
+        }
+    }
+}
+
+impl crate::proto::ToProto<crate::proto::BasicTypes::NftID> for NftId {
+    fn to_proto(&self) -> Result<crate::proto::BasicTypes::NftID, failure::Error> {
+        use crate::proto::ToProto;
+
+        let mut proto = crate::proto::BasicTypes::NftID::new();
+        proto.set_token_ID(self.token_id.to_proto()?);
+        proto.set_serial_number(self.serial);
+
+        Ok(proto)
+    }
+}
+
+/// The ID of an airdrop that is waiting for its receiver to claim it, or its sender to cancel
+/// it.
+#[derive(Debug, PartialEq, Clone, Copy)]
+pub enum PendingAirdropId {
+    /// An airdrop of a fungible token.
+    Fungible {
+        sender: AccountId,
+        receiver: AccountId,
+        token_id: TokenId,
+    },
+
+    /// An airdrop of a single NFT.
+    Nft {
+        sender: AccountId,
+        receiver: AccountId,
+        nft_id: NftId,
+    },
+}
+
+impl PendingAirdropId {
+    /// The account that sent the airdrop.
+    pub fn sender(&self) -> AccountId {
+        match *self {
+            PendingAirdropId::Fungible { sender, .. }
+            | PendingAirdropId::Nft { sender, .. } => sender,
+        }
+    }
+
+    /// The account that is to receive the airdrop.
+    pub fn receiver(&self) -> AccountId {
+        match *self {
+            PendingAirdropId::Fungible { receiver, .. }
+            | PendingAirdropId::Nft { receiver, .. } => receiver,
+        }
+    }
+}
+
+impl try_from::TryFrom<crate::proto::BasicTypes::PendingAirdropId> for PendingAirdropId {
+    type Err = failure::Error;
+
+    fn try_from(mut pb: crate::proto::BasicTypes::PendingAirdropId) -> Result<Self, Self::Err> {
+        use crate::proto::BasicTypes::PendingAirdropId_oneof_token_reference::*;
+
+        let sender = pb.take_sender_id().into();
+        let receiver = pb.take_receiver_id().into();
+
+        Ok(match pb.token_reference.take() {
+            Some(fungible_token_type(id)) => PendingAirdropId::Fungible {
+                sender,
+                receiver,
+                token_id: id.into(),
+            },
+
+            Some(non_fungible_token(id)) => PendingAirdropId::Nft {
+                sender,
+                receiver,
+                nft_id: id.into(),
+            },
+
+            None => Err(crate::ErrorKind::MissingField("token_reference"))?,
+        })
+    }
+}
+
+impl crate::proto::ToProto<crate::proto::BasicTypes::PendingAirdropId> for PendingAirdropId {
+    fn to_proto(&self) -> Result<crate::proto::BasicTypes::PendingAirdropId, failure::Error> {
+        use crate::proto::ToProto;
+
+        let mut proto = crate::proto::BasicTypes::PendingAirdropId::new();
+        proto.set_sender_id(self.sender().to_proto()?);
+        proto.set_receiver_id(self.receiver().to_proto()?);
+
+        match self {
+            PendingAirdropId::Fungible { token_id, .. } => {
+                proto.set_fungible_token_type(token_id.to_proto()?)
+            }
+
+            PendingAirdropId::Nft { nft_id, .. } => {
+                proto.set_non_fungible_token(nft_id.to_proto()?)
+            }
+        }
+
+        Ok(proto)
+    }
+}
@@ -1,6 +1,13 @@
-use crate::{crypto::PublicKey, proto, AccountId, Claim, ContractId, FileId};
+use crate::{
+    crypto::{Key, PublicKey},
+    proto::{self, SchedulableTransactionBody::SchedulableTransactionBody_oneof_data, ToProto},
+    transaction::{unschedulable, Transaction, TransactionRaw},
+    AccountId, Claim, Client, ContractId, FileId, HederaFunctionality, ScheduleId, TokenId,
+    TransactionId,
+};
 use chrono::{DateTime, Utc};
 use failure::Error;
+use protobuf::Message;
 use std::time::Duration;
 use try_from::{TryFrom, TryInto};
 
@@ -62,6 +69,12 @@ pub struct ContractInfo {
     pub expiration_time: DateTime<Utc>,
     pub auto_renew_period: Duration,
     pub storage: i64,
+    pub memo: String,
+    pub balance: u64,
+    pub deleted: bool,
+    pub token_relationships: Vec<TokenRelationship>,
+    pub auto_renew_account: Option<AccountId>,
+    pub max_automatic_token_associations: i32,
 }
 
 impl TryFrom<proto::ContractGetInfo::ContractGetInfoResponse_ContractInfo> for ContractInfo {
@@ -84,10 +97,62 @@ impl TryFrom<proto::ContractGetInfo::ContractGetInfoResponse_ContractInfo> for C
             expiration_time: info.take_expirationTime().into(),
             auto_renew_period: info.take_autoRenewPeriod().try_into()?,
             storage: info.get_storage(),
+            memo: info.take_memo(),
+            balance: info.get_balance(),
+            deleted: info.get_deleted(),
+            token_relationships: info
+                .take_tokenRelationships()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            auto_renew_account: if info.has_auto_renew_account_id() {
+                Some(info.take_auto_renew_account_id().into())
+            } else {
+                None
+            },
+            max_automatic_token_associations: info.get_max_automatic_token_associations(),
         })
     }
 }
 
+/// The relationship between an account or contract and a token it is associated with.
+#[derive(Debug)]
+pub struct TokenRelationship {
+    pub token_id: TokenId,
+    pub symbol: String,
+    pub balance: u64,
+    /// Whether the holder has been granted KYC, or `None` if the token has no KYC key.
+    pub kyc_granted: Option<bool>,
+    /// Whether the holder is frozen, or `None` if the token has no freeze key.
+    pub frozen: Option<bool>,
+    pub decimals: u32,
+    pub automatic_association: bool,
+}
+
+impl From<proto::BasicTypes::TokenRelationship> for TokenRelationship {
+    fn from(mut relationship: proto::BasicTypes::TokenRelationship) -> Self {
+        use self::proto::BasicTypes::{TokenFreezeStatus, TokenKycStatus};
+
+        Self {
+            token_id: relationship.take_tokenId().into(),
+            symbol: relationship.take_symbol(),
+            balance: relationship.get_balance(),
+            kyc_granted: match relationship.get_kycStatus() {
+                TokenKycStatus::KycNotApplicable => None,
+                TokenKycStatus::Granted => Some(true),
+                TokenKycStatus::Revoked => Some(false),
+            },
+            frozen: match relationship.get_freezeStatus() {
+                TokenFreezeStatus::FreezeNotApplicable => None,
+                TokenFreezeStatus::Frozen => Some(true),
+                TokenFreezeStatus::Unfrozen => Some(false),
+            },
+            decimals: relationship.get_decimals(),
+            automatic_association: relationship.get_automatic_association(),
+        }
+    }
+}
+
 #[derive(Debug)]
 pub struct FileInfo {
     pub file_id: FileId,
@@ -95,6 +160,7 @@ pub struct FileInfo {
     pub expiration_time: DateTime<Utc>,
     pub deleted: bool,
     pub keys: Vec<PublicKey>,
+    pub memo: String,
 }
 
 impl TryFrom<proto::FileGetInfo::FileGetInfoResponse_FileInfo> for FileInfo {
@@ -112,6 +178,149 @@ impl TryFrom<proto::FileGetInfo::FileGetInfoResponse_FileInfo> for FileInfo {
                 .into_iter()
                 .map(|k| k.try_into())
                 .collect::<Result<Vec<_>, _>>()?,
+            memo: info.take_memo(),
         })
     }
 }
+
+#[derive(Debug)]
+pub struct ScheduleInfo {
+    pub schedule_id: ScheduleId,
+    pub creator_account_id: AccountId,
+    pub payer_account_id: AccountId,
+    pub memo: String,
+    pub admin_key: Option<Key>,
+    /// The keys that have signed the scheduled transaction so far.
+    pub signatories: Vec<Key>,
+    pub expiration_time: DateTime<Utc>,
+    pub executed_at: Option<DateTime<Utc>>,
+    pub deleted_at: Option<DateTime<Utc>>,
+    /// The ID the scheduled transaction executes with, to query for its receipt or record.
+    pub scheduled_transaction_id: TransactionId,
+    pub scheduled_transaction: ScheduledTransactionBody,
+    /// Whether the scheduled transaction executes at the expiration time instead of as soon as
+    /// it has collected all the required signatures.
+    pub wait_for_expiry: bool,
+}
+
+impl TryFrom<proto::ScheduleGetInfo::ScheduleInfo> for ScheduleInfo {
+    type Err = Error;
+
+    fn try_from(mut info: proto::ScheduleGetInfo::ScheduleInfo) -> Result<Self, Error> {
+        use self::proto::ScheduleGetInfo::ScheduleInfo_oneof_data::*;
+
+        let admin_key = if info.has_adminKey() {
+            Some(info.take_adminKey().try_into()?)
+        } else {
+            None
+        };
+
+        let (executed_at, deleted_at) = match info.data.take() {
+            Some(execution_time(time)) => (Some(time.into()), None),
+            Some(deletion_time(time)) => (None, Some(time.into())),
+            None => (None, None),
+        };
+
+        let scheduled_transaction_id: TransactionId = info.take_scheduledTransactionID().into();
+
+        Ok(Self {
+            schedule_id: info.take_scheduleID().into(),
+            creator_account_id: info.take_creatorAccountID().into(),
+            payer_account_id: info.take_payerAccountID().into(),
+            memo: info.take_memo(),
+            admin_key,
+            signatories: info
+                .take_signers()
+                .take_keys()
+                .into_iter()
+                .map(|k| k.try_into())
+                .collect::<Result<Vec<_>, _>>()?,
+            expiration_time: info.take_expirationTime().into(),
+            executed_at,
+            deleted_at,
+            scheduled_transaction: ScheduledTransactionBody::new(
+                scheduled_transaction_id.clone(),
+                info.take_scheduledTransactionBody(),
+            ),
+            scheduled_transaction_id,
+            wait_for_expiry: info.get_wait_for_expiry(),
+        })
+    }
+}
+
+/// The body of a scheduled transaction, as stored in its schedule.
+#[derive(Debug, Clone)]
+pub struct ScheduledTransactionBody {
+    pub fee: u64,
+    pub memo: String,
+    transaction_id: TransactionId,
+    body: proto::SchedulableTransactionBody::SchedulableTransactionBody,
+}
+
+impl ScheduledTransactionBody {
+    fn new(
+        transaction_id: TransactionId,
+        body: proto::SchedulableTransactionBody::SchedulableTransactionBody,
+    ) -> Self {
+        Self {
+            fee: body.get_transactionFee(),
+            memo: body.get_memo().to_owned(),
+            transaction_id,
+            body,
+        }
+    }
+
+    /// The kind of transaction that is scheduled.
+    pub fn functionality(&self) -> HederaFunctionality {
+        use self::SchedulableTransactionBody_oneof_data::*;
+
+        match &self.body.data {
+            Some(contractCall(_)) => HederaFunctionality::ContractCall,
+            Some(contractCreateInstance(_)) => HederaFunctionality::ContractCreate,
+            Some(contractUpdateInstance(_)) => HederaFunctionality::ContractUpdate,
+            Some(contractDeleteInstance(_)) => HederaFunctionality::ContractDelete,
+            Some(cryptoCreateAccount(_)) => HederaFunctionality::CryptoCreate,
+            Some(cryptoDelete(_)) => HederaFunctionality::CryptoDelete,
+            Some(cryptoTransfer(_)) => HederaFunctionality::CryptoTransfer,
+            Some(cryptoUpdateAccount(_)) => HederaFunctionality::CryptoUpdate,
+            Some(fileAppend(_)) => HederaFunctionality::FileAppend,
+            Some(fileCreate(_)) => HederaFunctionality::FileCreate,
+            Some(fileDelete(_)) => HederaFunctionality::FileDelete,
+            Some(fileUpdate(_)) => HederaFunctionality::FileUpdate,
+            Some(systemDelete(_)) => HederaFunctionality::SystemDelete,
+            Some(systemUndelete(_)) => HederaFunctionality::SystemUndelete,
+            Some(freeze(_)) => HederaFunctionality::Freeze,
+            Some(scheduleDelete(_)) => HederaFunctionality::ScheduleDelete,
+
+            None => HederaFunctionality::None,
+        }
+    }
+
+    /// The protobuf encoding of the scheduled transaction body, for a full inspection of what
+    /// will be executed.
+    pub fn to_bytes(&self) -> Result<Vec<u8>, Error> {
+        Ok(self.body.write_to_bytes()?)
+    }
+
+    /// Decode the scheduled transaction, to be inspected like any other with the getters of
+    /// `Transaction`, such as `transfers`. It has the ID it executes with and no signatures.
+    ///
+    /// The transaction is only for inspection; it is executed by the network when the
+    /// schedule has collected enough signatures.
+    pub fn to_transaction(
+        &self,
+        client: &Client,
+    ) -> Result<Transaction<(), TransactionRaw>, Error> {
+        let mut body = proto::TransactionBody::TransactionBody::new();
+        body.set_transactionID(self.transaction_id.to_proto()?);
+        body.set_transactionFee(self.body.get_transactionFee());
+        body.set_memo(self.body.get_memo().to_owned());
+        body.data = self.body.data.clone().map(unschedulable);
+
+        let mut tx = proto::Transaction::Transaction::new();
+        tx.set_body(body);
+
+        Transaction::from_bytes(client, &tx.write_to_bytes()?)
+    }
+}
+
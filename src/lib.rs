@@ -1,44 +1,73 @@
 #![warn(clippy::pedantic, future_incompatible, unreachable_pub)]
 #![allow(clippy::stutter, clippy::new_ret_no_self, clippy::module_inception)]
 
-#[macro_use]
-mod macros;
-
+mod address_book;
 mod argument;
 pub mod call_params;
 mod call_param_utils;
 mod claim;
 pub mod client;
+mod contract_create_flow;
 mod crypto;
 mod duration;
 mod entity;
 mod error;
+mod ethereum_flow;
+mod exchange_rate;
+mod fee_schedule;
+mod grpc_web;
 mod id;
 mod info;
+mod observer;
+pub mod mirror;
+#[cfg(any(test, feature = "testenv"))]
+pub mod mock;
 mod proto;
 pub mod query;
+mod rlp;
 pub mod status;
 pub mod solidity_util;
 mod timestamp;
+#[cfg(feature = "testenv")]
+pub mod testenv;
 pub mod transaction;
 mod transaction_id;
 mod transaction_receipt;
 mod transaction_record;
+mod transaction_response;
+mod version_info;
 pub mod function_result;
 pub mod function_selector;
 
 pub use self::{
+    address_book::{NodeAddress, NodeAddressBook, ServiceEndpoint},
     claim::Claim,
     client::Client,
-    crypto::{PublicKey, SecretKey, Signature},
+    contract_create_flow::ContractCreateFlow,
+    crypto::{Key, PublicKey, SecretKey, Signature},
     entity::Entity,
     error::ErrorKind,
+    ethereum_flow::EthereumFlow,
+    exchange_rate::{ExchangeRate, ExchangeRates},
+    fee_schedule::{
+        FeeComponents, FeeData, FeeSchedule, FeeSchedules, HederaFunctionality,
+        TransactionFeeSchedule,
+    },
     id::*,
-    info::{AccountInfo, ContractInfo, FileInfo},
+    info::{
+        AccountInfo, ContractInfo, FileInfo, ScheduleInfo, ScheduledTransactionBody,
+        TokenRelationship,
+    },
+    observer::{Direction, Observer, RequestAttempt, RequestKind, WireMessage},
     status::Status,
     transaction_id::TransactionId,
     transaction_receipt::TransactionReceipt,
-    transaction_record::{TransactionRecord, TransactionRecordBody},
+    transaction_record::{
+        AssessedCustomFee, NftTransfer, PendingAirdropRecord, TokenAssociation, TokenTransferList,
+        TransactionRecord, TransactionRecordBody, Transfer,
+    },
+    transaction_response::TransactionResponse,
+    version_info::{NetworkVersionInfo, SemanticVersion, VERSION},
 };
 
 use once_cell::{sync::Lazy};
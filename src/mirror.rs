@@ -0,0 +1,160 @@
+mod mirror_address_book;
+mod mirror_api;
+mod mirror_contract_call;
+
+pub use self::{mirror_address_book::*, mirror_api::*, mirror_contract_call::*};
+
+use failure::{format_err, Error};
+use futures::{stream, Stream};
+use serde::{de::DeserializeOwned, Serialize};
+use serde_json::Value;
+use std::{
+    collections::VecDeque,
+    time::{Duration, Instant},
+};
+
+// How many times to retry a request the mirror node rejected for exceeding its rate limit
+const MAX_RATE_LIMIT_RETRIES: u32 = 5;
+
+/// A client for the REST API of a Hedera mirror node.
+///
+/// Mirror nodes serve the history and state of the network for free, without queries to
+/// (and fees for) the consensus nodes.
+pub struct MirrorClient {
+    http: reqwest::Client,
+    base_url: String,
+}
+
+impl MirrorClient {
+    /// Create a client for the mirror node REST API at `base_url`,
+    /// e.g. `https://testnet.mirrornode.hedera.com`.
+    pub fn new(base_url: impl Into<String>) -> Self {
+        Self {
+            http: reqwest::Client::new(),
+            base_url: base_url.into().trim_end_matches('/').to_owned(),
+        }
+    }
+
+    #[inline]
+    pub fn base_url(&self) -> &str {
+        &self.base_url
+    }
+
+    pub(crate) async fn get<T: DeserializeOwned>(&self, path: &str) -> Result<T, Error> {
+        let url = format!("{}{}", self.base_url, path);
+        let mut delay = Duration::from_millis(250);
+        let mut attempt = 0;
+
+        loop {
+            log::trace!(target: "hedera::mirror", "get: {}", url);
+
+            let response = self.http.get(&url).send().await?;
+
+            if response.status() == reqwest::StatusCode::TOO_MANY_REQUESTS
+                && attempt < MAX_RATE_LIMIT_RETRIES
+            {
+                log::debug!(target: "hedera::mirror", "rate limited; retrying in {:?}", delay);
+
+                attempt += 1;
+                tokio::timer::delay(Instant::now() + delay).await;
+                delay *= 2;
+
+                continue;
+            }
+
+            break parse_response(&url, response).await;
+        }
+    }
+
+    // Stream the items under `key` of every page of a listing at `path`, following the
+    // `links.next` of each page until the last
+    pub(crate) fn paginate<'a, T>(
+        &'a self,
+        path: String,
+        key: &'static str,
+    ) -> impl Stream<Item = Result<T, Error>> + 'a
+    where
+        T: DeserializeOwned + 'a,
+    {
+        stream::unfold((Some(path), VecDeque::new()), move |(mut next, mut items)| {
+            async move {
+                loop {
+                    if let Some(item) = items.pop_front() {
+                        return Some((Ok(item), (next, items)));
+                    }
+
+                    let path = next.take()?;
+
+                    match self.page(&path, key).await {
+                        Ok((page, link)) => {
+                            items.extend(page);
+                            next = link;
+                        }
+
+                        // stop at the first error
+                        Err(error) => return Some((Err(error), (None, items))),
+                    }
+                }
+            }
+        })
+    }
+
+    async fn page<T: DeserializeOwned>(
+        &self,
+        path: &str,
+        key: &str,
+    ) -> Result<(Vec<T>, Option<String>), Error> {
+        let mut page: Value = self.get(path).await?;
+
+        let items = match page.get_mut(key) {
+            Some(items) => serde_json::from_value(items.take())?,
+            None => Vec::new(),
+        };
+
+        let next = page.pointer("/links/next").and_then(Value::as_str).map(str::to_owned);
+
+        Ok((items, next))
+    }
+
+    pub(crate) async fn post<B: Serialize, T: DeserializeOwned>(
+        &self,
+        path: &str,
+        body: &B,
+    ) -> Result<T, Error> {
+        let url = format!("{}{}", self.base_url, path);
+        let body = serde_json::to_string(body)?;
+
+        log::trace!(target: "hedera::mirror", "post: {} {}", url, body);
+
+        let response = self
+            .http
+            .post(&url)
+            .header(reqwest::header::CONTENT_TYPE, "application/json")
+            .body(body)
+            .send()
+            .await?;
+
+        parse_response(&url, response).await
+    }
+}
+
+async fn parse_response<T: DeserializeOwned>(
+    url: &str,
+    response: reqwest::Response,
+) -> Result<T, Error> {
+    let status = response.status();
+    let body = response.text().await?;
+
+    log::trace!(target: "hedera::mirror", "recv: {} {}", status, body);
+
+    if !status.is_success() {
+        return Err(format_err!(
+            "mirror node request to {} failed with status {}: {}",
+            url,
+            status,
+            body
+        ));
+    }
+
+    Ok(serde_json::from_str(&body)?)
+}
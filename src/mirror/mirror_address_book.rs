@@ -0,0 +1,70 @@
+use crate::{
+    proto::{
+        MirrorNetworkService::AddressBookQuery, MirrorNetworkService_grpc::NetworkService as _,
+        ToProto,
+    },
+    Client, FileId, NodeAddress,
+};
+use failure::Error;
+use futures::{compat::Compat01As03, Stream, TryStreamExt};
+
+/// Get the nodes of an address book of the network from the mirror node.
+///
+/// The mirror node reconstructs the address book from its database, so unlike reading the file
+/// from a consensus node this is free and includes the service endpoints of every node.
+pub struct MirrorAddressBookQuery<'a> {
+    client: &'a Client,
+    file: FileId,
+    limit: i32,
+}
+
+impl<'a> MirrorAddressBookQuery<'a> {
+    pub fn new(client: &'a Client) -> Self {
+        Self {
+            client,
+            file: FileId::new(0, 0, 102),
+            limit: 0,
+        }
+    }
+
+    /// The address book file to get the nodes of, either `0:0:101` or `0:0:102` (the default).
+    #[inline]
+    pub fn file(&mut self, file: FileId) -> &mut Self {
+        self.file = file;
+        self
+    }
+
+    /// The maximum number of nodes to get. All nodes are returned by default.
+    #[inline]
+    pub fn limit(&mut self, limit: i32) -> &mut Self {
+        self.limit = limit;
+        self
+    }
+
+    /// Stream the nodes of the address book, in ascending order of node ID, as the mirror
+    /// node sends them.
+    pub fn subscribe(&self) -> Result<impl Stream<Item = Result<NodeAddress, Error>>, Error> {
+        let mut query = AddressBookQuery::new();
+        query.set_file_id(self.file.to_proto()?);
+        query.set_limit(self.limit);
+
+        log::trace!(target: "hedera::mirror", "sent: {:#?}", query);
+
+        let response = self
+            .client
+            .mirror_network_service()?
+            .get_nodes(grpc::RequestOptions::default(), query);
+
+        Ok(Compat01As03::new(response.drop_metadata())
+            .map_ok(NodeAddress::from)
+            .map_err(Error::from))
+    }
+
+    pub async fn execute_async(&self) -> Result<Vec<NodeAddress>, Error> {
+        self.subscribe()?.try_collect().await
+    }
+
+    pub fn execute(&self) -> Result<Vec<NodeAddress>, Error> {
+        crate::RUNTIME.lock().block_on(self.execute_async())
+    }
+}
@@ -0,0 +1,621 @@
+use crate::{
+    mirror::MirrorClient, timestamp::Timestamp, AccountId, ContractId, NftId, Status, TokenId,
+    TransactionId, TransactionReceipt,
+};
+use chrono::{DateTime, Utc};
+use failure::{format_err, Error};
+use futures::{stream, Stream, TryStreamExt};
+use serde::{de, Deserialize, Deserializer};
+use std::{
+    collections::VecDeque,
+    fmt::Display,
+    str::FromStr,
+    time::{Duration, Instant},
+};
+
+// The most results the mirror node returns in one page
+const PAGE_LIMIT: u32 = 100;
+
+/// The order the mirror node lists results in, by timestamp or ID.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum MirrorOrder {
+    Ascending,
+    Descending,
+}
+
+impl MirrorOrder {
+    fn as_str(self) -> &'static str {
+        match self {
+            MirrorOrder::Ascending => "asc",
+            MirrorOrder::Descending => "desc",
+        }
+    }
+}
+
+/// An account, as reported by the mirror node.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorAccount {
+    #[serde(deserialize_with = "parse")]
+    pub account: AccountId,
+    pub alias: Option<String>,
+    pub balance: MirrorBalance,
+    #[serde(default, deserialize_with = "parse_timestamp_opt")]
+    pub created_timestamp: Option<DateTime<Utc>>,
+    #[serde(default)]
+    pub deleted: bool,
+    /// The 20-byte EVM address of the account, as hex.
+    pub evm_address: Option<String>,
+    #[serde(default)]
+    pub memo: String,
+}
+
+/// The hbar and token balances of an account at a point in time.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorBalance {
+    /// The hbar balance, in tinybars.
+    pub balance: i64,
+    #[serde(default, deserialize_with = "parse_timestamp_opt")]
+    pub timestamp: Option<DateTime<Utc>>,
+    #[serde(default)]
+    pub tokens: Vec<MirrorTokenBalance>,
+}
+
+/// The balance of an account in one token, in its lowest denomination.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorTokenBalance {
+    #[serde(deserialize_with = "parse")]
+    pub token_id: TokenId,
+    pub balance: i64,
+}
+
+/// The relationship of an account with a token it is associated with.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorTokenRelationship {
+    #[serde(deserialize_with = "parse")]
+    pub token_id: TokenId,
+    /// The balance of the account in the token, in its lowest denomination; the number of
+    /// NFTs owned for a non-fungible token.
+    pub balance: i64,
+    pub decimals: Option<u32>,
+    /// Whether the association was made automatically, by a transfer to the account.
+    #[serde(default)]
+    pub automatic_association: bool,
+    /// `FROZEN`, `UNFROZEN`, or `NOT_APPLICABLE`.
+    pub freeze_status: Option<String>,
+    /// `GRANTED`, `REVOKED`, or `NOT_APPLICABLE`.
+    pub kyc_status: Option<String>,
+}
+
+/// A transaction, as reported by the mirror node.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorTransaction {
+    /// The ID of the transaction, without its `scheduled` flag and `nonce`.
+    #[serde(deserialize_with = "parse_transaction_id")]
+    pub transaction_id: TransactionId,
+    #[serde(default)]
+    pub scheduled: bool,
+    /// The nonce of a child transaction; `0` for any other transaction.
+    #[serde(default)]
+    pub nonce: i32,
+    #[serde(deserialize_with = "parse_timestamp")]
+    pub consensus_timestamp: DateTime<Utc>,
+    /// The name of the transaction, e.g. `CRYPTOTRANSFER`.
+    pub name: String,
+    /// The name of the status of the transaction, e.g. `SUCCESS`.
+    pub result: String,
+    /// The fee charged for the transaction, in tinybars.
+    pub charged_tx_fee: i64,
+    /// The memo of the transaction, as base64.
+    #[serde(default)]
+    pub memo_base64: String,
+    #[serde(default, deserialize_with = "parse_opt")]
+    pub node: Option<AccountId>,
+    /// The entity created or changed by the transaction, if any.
+    pub entity_id: Option<String>,
+    #[serde(default)]
+    pub transfers: Vec<MirrorTransfer>,
+    #[serde(default)]
+    pub token_transfers: Vec<MirrorTokenTransfer>,
+    #[serde(default)]
+    pub nft_transfers: Vec<MirrorNftTransfer>,
+    /// The SHA-384 hash of the transaction, as base64.
+    pub transaction_hash: String,
+}
+
+impl MirrorTransaction {
+    /// Whether the transaction succeeded.
+    pub fn is_success(&self) -> bool {
+        self.result == "SUCCESS"
+    }
+
+    /// A receipt of the transaction, with its status and the entity it created.
+    ///
+    /// Unlike the receipt from a consensus node, this has none of the other fields,
+    /// such as the exchange rates or the serial numbers of a mint.
+    pub fn receipt(&self) -> Result<TransactionReceipt, Error> {
+        let mut receipt = TransactionReceipt::with_status(self.result.parse()?);
+
+        if receipt.status != Status::Success {
+            return Ok(receipt);
+        }
+
+        if let Some(entity) = &self.entity_id {
+            match &*self.name {
+                "CRYPTOCREATEACCOUNT" => receipt.account_id = Some(Box::new(entity.parse()?)),
+                "FILECREATE" => receipt.file_id = Some(Box::new(entity.parse()?)),
+                "CONTRACTCREATEINSTANCE" => receipt.contract_id = Some(Box::new(entity.parse()?)),
+                "CONSENSUSCREATETOPIC" => receipt.topic_id = Some(Box::new(entity.parse()?)),
+                "TOKENCREATION" => receipt.token_id = Some(Box::new(entity.parse()?)),
+                "SCHEDULECREATE" => receipt.schedule_id = Some(Box::new(entity.parse()?)),
+
+                _ => {}
+            }
+        }
+
+        Ok(receipt)
+    }
+}
+
+/// An hbar transfer made by a transaction.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorTransfer {
+    #[serde(deserialize_with = "parse")]
+    pub account: AccountId,
+    pub amount: i64,
+    #[serde(default)]
+    pub is_approval: bool,
+}
+
+/// A transfer of a fungible token made by a transaction.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorTokenTransfer {
+    #[serde(deserialize_with = "parse")]
+    pub token_id: TokenId,
+    #[serde(deserialize_with = "parse")]
+    pub account: AccountId,
+    pub amount: i64,
+    #[serde(default)]
+    pub is_approval: bool,
+}
+
+/// A transfer of an NFT made by a transaction.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorNftTransfer {
+    #[serde(deserialize_with = "parse")]
+    pub token_id: TokenId,
+    pub serial_number: i64,
+    /// The sender; `None` for a mint.
+    #[serde(default, deserialize_with = "parse_opt")]
+    pub sender_account_id: Option<AccountId>,
+    /// The receiver; `None` for a burn or wipe.
+    #[serde(default, deserialize_with = "parse_opt")]
+    pub receiver_account_id: Option<AccountId>,
+    #[serde(default)]
+    pub is_approval: bool,
+}
+
+/// A token, as reported by the mirror node.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorToken {
+    #[serde(deserialize_with = "parse")]
+    pub token_id: TokenId,
+    pub name: String,
+    pub symbol: String,
+    #[serde(deserialize_with = "parse")]
+    pub decimals: u32,
+    #[serde(deserialize_with = "parse")]
+    pub total_supply: u64,
+    /// `FUNGIBLE_COMMON` or `NON_FUNGIBLE_UNIQUE`.
+    #[serde(rename = "type")]
+    pub token_type: String,
+    #[serde(default, deserialize_with = "parse_opt")]
+    pub treasury_account_id: Option<AccountId>,
+    #[serde(default)]
+    pub memo: String,
+    #[serde(default)]
+    pub deleted: bool,
+}
+
+/// An NFT, as reported by the mirror node.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorNft {
+    #[serde(deserialize_with = "parse")]
+    pub token_id: TokenId,
+    pub serial_number: i64,
+    /// The owner of the NFT; `None` once it is burned.
+    #[serde(default, deserialize_with = "parse_opt")]
+    pub account_id: Option<AccountId>,
+    /// The metadata of the NFT, as base64.
+    #[serde(default)]
+    pub metadata: String,
+    #[serde(default)]
+    pub deleted: bool,
+    #[serde(default, deserialize_with = "parse_timestamp_opt")]
+    pub created_timestamp: Option<DateTime<Utc>>,
+}
+
+impl MirrorNft {
+    #[inline]
+    pub fn nft_id(&self) -> NftId {
+        NftId::new(self.token_id, self.serial_number)
+    }
+}
+
+/// The result of a contract call or creation, as reported by the mirror node.
+#[derive(Debug, Clone, Deserialize)]
+pub struct MirrorContractResult {
+    #[serde(default, deserialize_with = "parse_opt")]
+    pub contract_id: Option<ContractId>,
+    /// The EVM address of the caller, as hex.
+    pub from: Option<String>,
+    /// The EVM address of the contract, as hex.
+    pub to: Option<String>,
+    /// The ABI encoded result of the call, as hex.
+    pub call_result: Option<String>,
+    pub error_message: Option<String>,
+    pub gas_limit: u64,
+    pub gas_used: Option<u64>,
+    /// The name of the status of the transaction, e.g. `SUCCESS`.
+    pub result: String,
+    #[serde(deserialize_with = "parse_timestamp")]
+    pub timestamp: DateTime<Utc>,
+}
+
+#[derive(Deserialize)]
+struct Transactions {
+    transactions: Vec<MirrorTransaction>,
+}
+
+#[derive(Deserialize)]
+struct Balances {
+    #[serde(default, deserialize_with = "parse_timestamp_opt")]
+    timestamp: Option<DateTime<Utc>>,
+    balances: Vec<AccountBalance>,
+}
+
+#[derive(Deserialize)]
+struct AccountBalance {
+    balance: i64,
+    #[serde(default)]
+    tokens: Vec<MirrorTokenBalance>,
+}
+
+impl MirrorClient {
+    pub async fn account_async(&self, id: AccountId) -> Result<MirrorAccount, Error> {
+        self.get(&format!("/api/v1/accounts/{}", entity(id))).await
+    }
+
+    pub fn account(&self, id: AccountId) -> Result<MirrorAccount, Error> {
+        crate::RUNTIME.lock().block_on(self.account_async(id))
+    }
+
+    /// The latest hbar and token balances of an account.
+    ///
+    /// The balances of the mirror node are snapshots taken every few minutes, so they can lag
+    /// behind the network.
+    pub async fn account_balance_async(&self, id: AccountId) -> Result<MirrorBalance, Error> {
+        let path = format!("/api/v1/balances?account.id={}", entity(id));
+        let response: Balances = self.get(&path).await?;
+
+        let balance = response
+            .balances
+            .into_iter()
+            .next()
+            .ok_or_else(|| format_err!("mirror node has no balance for {}", id))?;
+
+        Ok(MirrorBalance {
+            balance: balance.balance,
+            timestamp: response.timestamp,
+            tokens: balance.tokens,
+        })
+    }
+
+    pub fn account_balance(&self, id: AccountId) -> Result<MirrorBalance, Error> {
+        crate::RUNTIME.lock().block_on(self.account_balance_async(id))
+    }
+
+    /// Stream the tokens an account is associated with, with its balance in each.
+    ///
+    /// Unlike the balance query of a consensus node, this includes every token of the account.
+    pub fn account_tokens(
+        &self,
+        id: AccountId,
+    ) -> impl Stream<Item = Result<MirrorTokenRelationship, Error>> + '_ {
+        let path = format!("/api/v1/accounts/{}/tokens?limit={}", entity(id), PAGE_LIMIT);
+
+        self.paginate(path, "tokens")
+    }
+
+    /// Every token an account is associated with, with its balance in each, following all
+    /// pages of the mirror node.
+    pub async fn account_token_holdings_async(
+        &self,
+        id: AccountId,
+    ) -> Result<Vec<MirrorTokenRelationship>, Error> {
+        self.account_tokens(id).try_collect().await
+    }
+
+    pub fn account_token_holdings(
+        &self,
+        id: AccountId,
+    ) -> Result<Vec<MirrorTokenRelationship>, Error> {
+        crate::RUNTIME.lock().block_on(self.account_token_holdings_async(id))
+    }
+
+    /// Stream the NFTs owned by an account, with their metadata.
+    pub fn account_nfts(
+        &self,
+        id: AccountId,
+    ) -> impl Stream<Item = Result<MirrorNft, Error>> + '_ {
+        let path = format!("/api/v1/accounts/{}/nfts?limit={}", entity(id), PAGE_LIMIT);
+
+        self.paginate(path, "nfts")
+    }
+
+    /// Every NFT owned by an account, with their metadata, following all pages of the
+    /// mirror node.
+    pub async fn account_nft_holdings_async(&self, id: AccountId) -> Result<Vec<MirrorNft>, Error> {
+        self.account_nfts(id).try_collect().await
+    }
+
+    pub fn account_nft_holdings(&self, id: AccountId) -> Result<Vec<MirrorNft>, Error> {
+        crate::RUNTIME.lock().block_on(self.account_nft_holdings_async(id))
+    }
+
+    /// The transactions with an ID, in consensus order; there is more than one if the ID was
+    /// submitted more than once.
+    pub async fn transaction_async(
+        &self,
+        id: &TransactionId,
+    ) -> Result<Vec<MirrorTransaction>, Error> {
+        let mut path = format!("/api/v1/transactions/{}", mirror_transaction_id(id));
+
+        if id.scheduled {
+            path.push_str("?scheduled=true");
+        } else if id.nonce != 0 {
+            path.push_str(&format!("?nonce={}", id.nonce));
+        }
+
+        let response: Transactions = self.get(&path).await?;
+
+        Ok(response.transactions)
+    }
+
+    pub fn transaction(&self, id: &TransactionId) -> Result<Vec<MirrorTransaction>, Error> {
+        crate::RUNTIME.lock().block_on(self.transaction_async(id))
+    }
+
+    /// Stream the transactions an account was involved in, fetching more pages from the
+    /// mirror node as they are read.
+    pub fn account_transactions(
+        &self,
+        id: AccountId,
+        order: MirrorOrder,
+    ) -> impl Stream<Item = Result<MirrorTransaction, Error>> + '_ {
+        let path = format!(
+            "/api/v1/transactions?account.id={}&order={}&limit={}",
+            entity(id),
+            order.as_str(),
+            PAGE_LIMIT
+        );
+
+        self.paginate(path, "transactions")
+    }
+
+    /// Stream the transactions an account is involved in from now on, as they reach the
+    /// mirror node, polling it every `interval` while there are none.
+    ///
+    /// The stream never ends. A failed poll is yielded as an error and polling continues.
+    pub fn subscribe_account_transactions(
+        &self,
+        id: AccountId,
+        interval: Duration,
+    ) -> impl Stream<Item = Result<MirrorTransaction, Error>> + '_ {
+        let state = (Utc::now(), VecDeque::new(), false);
+
+        stream::unfold(state, move |(mut after, mut transactions, mut wait)| {
+            async move {
+                loop {
+                    if let Some(transaction) = transactions.pop_front() {
+                        return Some((Ok(transaction), (after, transactions, wait)));
+                    }
+
+                    if wait {
+                        tokio::timer::delay(Instant::now() + interval).await;
+                    }
+
+                    let path = format!(
+                        "/api/v1/transactions?account.id={}&timestamp=gt:{}&order=asc&limit={}",
+                        entity(id),
+                        mirror_timestamp(after),
+                        PAGE_LIMIT
+                    );
+
+                    let page: Transactions = match self.get(&path).await {
+                        Ok(page) => page,
+                        Err(error) => return Some((Err(error), (after, transactions, true))),
+                    };
+
+                    // poll again straight away while the mirror node has a backlog
+                    wait = page.transactions.len() < PAGE_LIMIT as usize;
+
+                    if let Some(last) = page.transactions.last() {
+                        after = last.consensus_timestamp;
+                    }
+
+                    transactions.extend(page.transactions);
+                }
+            }
+        })
+    }
+
+    pub async fn token_async(&self, id: TokenId) -> Result<MirrorToken, Error> {
+        self.get(&format!("/api/v1/tokens/{}", entity(id))).await
+    }
+
+    pub fn token(&self, id: TokenId) -> Result<MirrorToken, Error> {
+        crate::RUNTIME.lock().block_on(self.token_async(id))
+    }
+
+    pub async fn nft_async(&self, id: NftId) -> Result<MirrorNft, Error> {
+        let path = format!("/api/v1/tokens/{}/nfts/{}", entity(id.token_id), id.serial);
+
+        self.get(&path).await
+    }
+
+    pub fn nft(&self, id: NftId) -> Result<MirrorNft, Error> {
+        crate::RUNTIME.lock().block_on(self.nft_async(id))
+    }
+
+    /// Stream the NFTs of a token, fetching more pages from the mirror node as they are read.
+    pub fn token_nfts(
+        &self,
+        id: TokenId,
+        order: MirrorOrder,
+    ) -> impl Stream<Item = Result<MirrorNft, Error>> + '_ {
+        let path = format!(
+            "/api/v1/tokens/{}/nfts?order={}&limit={}",
+            entity(id),
+            order.as_str(),
+            PAGE_LIMIT
+        );
+
+        self.paginate(path, "nfts")
+    }
+
+    /// The result of the contract call or creation made by a transaction.
+    pub async fn contract_result_async(
+        &self,
+        id: &TransactionId,
+    ) -> Result<MirrorContractResult, Error> {
+        let path = format!("/api/v1/contracts/results/{}", mirror_transaction_id(id));
+
+        self.get(&path).await
+    }
+
+    pub fn contract_result(&self, id: &TransactionId) -> Result<MirrorContractResult, Error> {
+        crate::RUNTIME.lock().block_on(self.contract_result_async(id))
+    }
+}
+
+// The mirror node takes entity IDs as `{shard}.{realm}.{num}`
+pub(crate) fn entity(id: impl Display) -> String {
+    id.to_string().replace(':', ".")
+}
+
+// The mirror node takes transaction IDs as `{shard}.{realm}.{num}-{seconds}-{nanos}`
+pub(crate) fn mirror_transaction_id(id: &TransactionId) -> String {
+    format!(
+        "{}-{}-{:09}",
+        entity(id.account_id),
+        id.transaction_valid_start.timestamp(),
+        id.transaction_valid_start.timestamp_subsec_nanos()
+    )
+}
+
+// The mirror node takes timestamps as `{seconds}.{nanos}`
+fn mirror_timestamp(timestamp: DateTime<Utc>) -> String {
+    format!("{}.{:09}", timestamp.timestamp(), timestamp.timestamp_subsec_nanos())
+}
+
+fn parse<'de, D, T>(deserializer: D) -> Result<T, D::Error>
+where
+    D: Deserializer<'de>,
+    T: FromStr,
+    T::Err: Display,
+{
+    String::deserialize(deserializer)?
+        .parse()
+        .map_err(de::Error::custom)
+}
+
+fn parse_opt<'de, D, T>(deserializer: D) -> Result<Option<T>, D::Error>
+where
+    D: Deserializer<'de>,
+    T: FromStr,
+    T::Err: Display,
+{
+    match Option::<String>::deserialize(deserializer)? {
+        Some(s) => s.parse().map(Some).map_err(de::Error::custom),
+        None => Ok(None),
+    }
+}
+
+fn parse_timestamp<'de, D>(deserializer: D) -> Result<DateTime<Utc>, D::Error>
+where
+    D: Deserializer<'de>,
+{
+    let timestamp: Timestamp = parse(deserializer)?;
+
+    Ok(timestamp.into())
+}
+
+fn parse_timestamp_opt<'de, D>(deserializer: D) -> Result<Option<DateTime<Utc>>, D::Error>
+where
+    D: Deserializer<'de>,
+{
+    let timestamp: Option<Timestamp> = parse_opt(deserializer)?;
+
+    Ok(timestamp.map(Into::into))
+}
+
+fn parse_transaction_id<'de, D>(deserializer: D) -> Result<TransactionId, D::Error>
+where
+    D: Deserializer<'de>,
+{
+    let id = String::deserialize(deserializer)?;
+
+    let mut parts = id.rsplitn(3, '-');
+
+    match (parts.next(), parts.next(), parts.next()) {
+        (Some(nanos), Some(seconds), Some(account)) => {
+            let timestamp = format!("{}.{}", seconds, nanos);
+            let timestamp: Timestamp = timestamp.parse().map_err(de::Error::custom)?;
+
+            Ok(TransactionId::with_valid_start(
+                account.parse().map_err(de::Error::custom)?,
+                timestamp.into(),
+            ))
+        }
+
+        _ => Err(de::Error::custom(format!("invalid transaction ID: {}", id))),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::{mirror_transaction_id, MirrorTransaction};
+    use crate::{timestamp::Timestamp, AccountId, TransactionId};
+    use failure::Error;
+
+    #[test]
+    fn test_parse_transaction() -> Result<(), Error> {
+        let transaction: MirrorTransaction = serde_json::from_str(
+            r#"{
+                "transaction_id": "0.0.1001-1234567890-000000001",
+                "consensus_timestamp": "1234567891.000000002",
+                "name": "CRYPTOTRANSFER",
+                "result": "SUCCESS",
+                "charged_tx_fee": 84650,
+                "node": "0.0.3",
+                "transfers": [{ "account": "0.0.1001", "amount": -100 }],
+                "transaction_hash": "aGFzaA=="
+            }"#,
+        )?;
+
+        let id = TransactionId::with_valid_start(
+            AccountId::new(0, 0, 1001),
+            Timestamp(1234567890, 1).into(),
+        );
+
+        assert_eq!(transaction.transaction_id, id);
+        assert_eq!(transaction.consensus_timestamp.timestamp(), 1234567891);
+        assert_eq!(transaction.node, Some(AccountId::new(0, 0, 3)));
+        assert_eq!(transaction.transfers[0].amount, -100);
+        assert!(transaction.is_success());
+
+        assert_eq!(mirror_transaction_id(&id), "0.0.1001-1234567890-000000001");
+
+        Ok(())
+    }
+}
@@ -0,0 +1,156 @@
+use crate::{
+    call_params::CallParams, mirror::MirrorClient, solidity_util::address_for_account,
+    solidity_util::address_for_contract, AccountId, Client, ContractId,
+};
+use failure::{format_err, Error};
+use serde::{Deserialize, Serialize};
+
+/// Call a function of a smart contract, or estimate the gas a call would need, through the
+/// mirror node.
+///
+/// Unlike `QueryContractCall`, this does not pay a consensus node for the query. The call is
+/// simulated by the mirror node against the latest state (or the state at a given block) and
+/// cannot change it.
+pub struct MirrorNodeContractQuery<'a> {
+    client: &'a Client,
+    contract: ContractId,
+    function_parameters: Vec<u8>,
+    sender: Option<String>,
+    gas: Option<i64>,
+    gas_price: Option<i64>,
+    value: Option<i64>,
+    block_number: Option<u64>,
+}
+
+#[derive(Serialize)]
+#[serde(rename_all = "camelCase")]
+struct ContractCallRequest {
+    data: String,
+    to: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    from: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    gas: Option<i64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    gas_price: Option<i64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    value: Option<i64>,
+    estimate: bool,
+    block: String,
+}
+
+#[derive(Deserialize)]
+struct ContractCallResponse {
+    result: String,
+}
+
+impl<'a> MirrorNodeContractQuery<'a> {
+    pub fn new(client: &'a Client, contract: ContractId) -> Self {
+        Self {
+            client,
+            contract,
+            function_parameters: Vec::new(),
+            sender: None,
+            gas: None,
+            gas_price: None,
+            value: None,
+            block_number: None,
+        }
+    }
+
+    #[inline]
+    pub fn function_parameters(&mut self, parameters: Vec<u8>) -> &mut Self {
+        self.function_parameters = parameters;
+        self
+    }
+
+    #[inline]
+    pub fn call_params(&mut self, params: &CallParams) -> &mut Self {
+        self.function_parameters(params.assemble())
+    }
+
+    /// Sets the account that is the "sender" of the call.
+    #[inline]
+    pub fn sender(&mut self, account: AccountId) -> &mut Self {
+        self.sender = Some(address_for_account(account));
+        self
+    }
+
+    /// Sets the 20-byte EVM address, as hex, that is the "sender" of the call.
+    #[inline]
+    pub fn sender_evm_address(&mut self, address: impl Into<String>) -> &mut Self {
+        self.sender = Some(address.into().trim_start_matches("0x").to_owned());
+        self
+    }
+
+    #[inline]
+    pub fn gas(&mut self, gas: i64) -> &mut Self {
+        self.gas = Some(gas);
+        self
+    }
+
+    #[inline]
+    pub fn gas_price(&mut self, price: i64) -> &mut Self {
+        self.gas_price = Some(price);
+        self
+    }
+
+    /// Sets the number of tinybars sent to the contract with the call.
+    #[inline]
+    pub fn value(&mut self, value: i64) -> &mut Self {
+        self.value = Some(value);
+        self
+    }
+
+    /// Run the call against the state at this block instead of the latest state.
+    #[inline]
+    pub fn block_number(&mut self, block: u64) -> &mut Self {
+        self.block_number = Some(block);
+        self
+    }
+
+    /// Call the function and return the raw, ABI encoded result.
+    pub async fn call_async(&self) -> Result<Vec<u8>, Error> {
+        let result = self.send(false).await?;
+
+        Ok(hex::decode(result.trim_start_matches("0x"))?)
+    }
+
+    pub fn call(&self) -> Result<Vec<u8>, Error> {
+        crate::RUNTIME.lock().block_on(self.call_async())
+    }
+
+    /// Estimate the gas needed to execute the call as a transaction.
+    pub async fn estimate_gas_async(&self) -> Result<u64, Error> {
+        let result = self.send(true).await?;
+
+        u64::from_str_radix(result.trim_start_matches("0x"), 16)
+            .map_err(|_| format_err!("mirror node returned an invalid gas estimate: {}", result))
+    }
+
+    pub fn estimate_gas(&self) -> Result<u64, Error> {
+        crate::RUNTIME.lock().block_on(self.estimate_gas_async())
+    }
+
+    async fn send(&self, estimate: bool) -> Result<String, Error> {
+        let mirror: &MirrorClient = self.client.mirror()?;
+
+        let request = ContractCallRequest {
+            data: format!("0x{}", hex::encode(&self.function_parameters)),
+            to: format!("0x{}", address_for_contract(self.contract)),
+            from: self.sender.as_ref().map(|sender| format!("0x{}", sender)),
+            gas: self.gas,
+            gas_price: self.gas_price,
+            value: self.value,
+            estimate,
+            block: match self.block_number {
+                Some(block) => block.to_string(),
+                None => "latest".to_owned(),
+            },
+        };
+
+        let response: ContractCallResponse = mirror.post("/api/v1/contracts/call", &request).await?;
+
+        Ok(response.result)
+    }
+}
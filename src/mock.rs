@@ -0,0 +1,562 @@
+//! A local stand-in for a node of the network, to unit test code that uses a `Client`
+//! without a network.
+//!
+//! A [`MockNetwork`] serves every service of a node on a port of `127.0.0.1`. Transactions
+//! and queries sent to it are answered from queues of programmed answers, in order, then
+//! with success.
+//!
+//! Built for the tests of this crate, and for other crates with the `testenv` feature.
+//!
+//! [`MockNetwork`]: struct.MockNetwork.html
+
+use crate::{
+    proto::{
+        AddressBookService_grpc::{AddressBookService, AddressBookServiceServer},
+        CryptoGetAccountBalance::CryptoGetAccountBalanceResponse,
+        CryptoService_grpc::{CryptoService, CryptoServiceServer},
+        FileService_grpc::{FileService, FileServiceServer},
+        FreezeService_grpc::{FreezeService, FreezeServiceServer},
+        NetworkService_grpc::{NetworkService, NetworkServiceServer},
+        Query::{Query, Query_oneof_query},
+        QueryHeader::{QueryHeader, ResponseType},
+        Response::{Response, Response_oneof_response},
+        ResponseHeader::ResponseHeader,
+        ScheduleService_grpc::{ScheduleService, ScheduleServiceServer},
+        SmartContractService_grpc::{SmartContractService, SmartContractServiceServer},
+        Transaction::Transaction,
+        TransactionGetReceipt::TransactionGetReceiptResponse,
+        TransactionResponse::TransactionResponse,
+        UtilService_grpc::{UtilService, UtilServiceServer},
+    },
+    Client, Status, TransactionReceipt, TransactionRecord,
+};
+use failure::{err_msg, Error};
+use parking_lot::Mutex;
+use protobuf::Message;
+use std::{collections::VecDeque, sync::Arc};
+
+/// How the mock network answers the next query.
+enum Answer {
+    Status(Status),
+    Receipt(TransactionReceipt),
+    Record(TransactionRecord),
+    Balance(u64),
+}
+
+#[derive(Default)]
+struct MockState {
+    transactions: VecDeque<Status>,
+    queries: VecDeque<Answer>,
+    submitted: Vec<Vec<u8>>,
+}
+
+/// A local node that answers transactions and queries as it is told to.
+///
+/// The server stops when this is dropped.
+pub struct MockNetwork {
+    state: Arc<Mutex<MockState>>,
+    address: String,
+    // kept to keep the server running
+    _server: grpc::Server,
+}
+
+impl MockNetwork {
+    /// Start serving on a free port of `127.0.0.1`.
+    pub fn start() -> Result<Self, Error> {
+        let state = Arc::new(Mutex::new(MockState::default()));
+        let service = MockService {
+            state: state.clone(),
+        };
+
+        let mut server = grpc::ServerBuilder::new_plain();
+        server.http.set_addr("127.0.0.1:0")?;
+
+        server.add_service(CryptoServiceServer::new_service_def(service.clone()));
+        server.add_service(FileServiceServer::new_service_def(service.clone()));
+        server.add_service(SmartContractServiceServer::new_service_def(service.clone()));
+        server.add_service(ScheduleServiceServer::new_service_def(service.clone()));
+        server.add_service(FreezeServiceServer::new_service_def(service.clone()));
+        server.add_service(AddressBookServiceServer::new_service_def(service.clone()));
+        server.add_service(UtilServiceServer::new_service_def(service.clone()));
+        server.add_service(NetworkServiceServer::new_service_def(service));
+
+        let server = server.build()?;
+
+        let port = match server.local_addr() {
+            httpbis::AnySocketAddr::Inet(addr) => addr.port(),
+            _ => Err(err_msg("mock network is not listening on a TCP port"))?,
+        };
+
+        Ok(Self {
+            state,
+            address: format!("127.0.0.1:{}", port),
+            _server: server,
+        })
+    }
+
+    /// The `host:port` address the mock network is listening on.
+    #[inline]
+    pub fn address(&self) -> &str {
+        &self.address
+    }
+
+    /// A client of the mock network, with node `0:0:3` and no operator.
+    pub fn client(&self) -> Result<Client, Error> {
+        let mut client = Client::new(&self.address)?;
+        client.set_node("0:0:3".parse()?);
+
+        Ok(client)
+    }
+
+    /// Answer the next transaction with a precheck of `status`.
+    pub fn push_transaction(&self, status: Status) {
+        self.state.lock().transactions.push_back(status);
+    }
+
+    /// Answer the next query with a precheck of `status`, e.g. `Status::Busy`.
+    pub fn push_query_status(&self, status: Status) {
+        self.state.lock().queries.push_back(Answer::Status(status));
+    }
+
+    /// Answer the next query, which must be for a receipt, with `receipt`.
+    ///
+    /// Receipt queries with nothing programmed are answered with a `Success` receipt.
+    pub fn push_receipt(&self, receipt: TransactionReceipt) {
+        self.state.lock().queries.push_back(Answer::Receipt(receipt));
+    }
+
+    /// Answer the next query, which must be for a record, with `record`.
+    pub fn push_record(&self, record: TransactionRecord) {
+        self.state.lock().queries.push_back(Answer::Record(record));
+    }
+
+    /// Answer the next query, which must be for an account balance, with `balance` tinybars.
+    pub fn push_balance(&self, balance: u64) {
+        self.state.lock().queries.push_back(Answer::Balance(balance));
+    }
+
+    /// The bytes of every transaction submitted so far, in order, including any that were
+    /// answered with a failed precheck.
+    ///
+    /// Each can be read back with `Transaction::from_bytes`.
+    pub fn submitted(&self) -> Vec<Vec<u8>> {
+        self.state.lock().submitted.clone()
+    }
+}
+
+#[derive(Clone)]
+struct MockService {
+    state: Arc<Mutex<MockState>>,
+}
+
+impl MockService {
+    fn transaction(&self, transaction: Transaction) -> TransactionResponse {
+        let mut state = self.state.lock();
+
+        // note: cannot fail
+        state.submitted.push(transaction.write_to_bytes().unwrap_or_default());
+
+        let status = state.transactions.pop_front().unwrap_or(Status::Ok);
+
+        let mut response = TransactionResponse::new();
+        response.set_nodeTransactionPrecheckCode(status.into());
+
+        response
+    }
+
+    fn query(&self, query: Query) -> Response {
+        let mut header = ResponseHeader::new();
+        header.set_nodeTransactionPrecheckCode(Status::Ok.into());
+
+        let mut response = Response::new();
+
+        // asking for the cost does not use up an answer
+        if query_header(&query).get_responseType() != ResponseType::ANSWER_ONLY {
+            header.set_responseType(ResponseType::COST_ANSWER);
+            response.response = Some(Response_oneof_response::transactionGetReceipt(
+                with_header(header),
+            ));
+
+            return response;
+        }
+
+        let answer = match self.state.lock().queries.pop_front() {
+            Some(answer) => answer,
+
+            None => match query.query {
+                Some(Query_oneof_query::transactionGetReceipt(_)) => {
+                    Answer::Receipt(TransactionReceipt::with_status(Status::Success))
+                }
+
+                _ => Answer::Status(Status::NotSupported),
+            },
+        };
+
+        response.response = Some(match answer {
+            Answer::Status(status) => {
+                header.set_nodeTransactionPrecheckCode(status.into());
+
+                // the envelope does not matter if the query failed
+                Response_oneof_response::transactionGetReceipt(with_header(header))
+            }
+
+            Answer::Receipt(receipt) => match receipt.to_response() {
+                Ok(mut receipt) => {
+                    receipt.set_header(header);
+                    Response_oneof_response::transactionGetReceipt(receipt)
+                }
+
+                Err(error) => return failed(&error),
+            },
+
+            Answer::Record(record) => match record.to_response() {
+                Ok(mut record) => {
+                    record.set_header(header);
+                    Response_oneof_response::transactionGetRecord(record)
+                }
+
+                Err(error) => return failed(&error),
+            },
+
+            Answer::Balance(balance) => {
+                let mut data = CryptoGetAccountBalanceResponse::new();
+                data.set_header(header);
+                data.set_balance(balance);
+
+                if let Some(Query_oneof_query::cryptogetAccountBalance(query)) = &query.query {
+                    data.set_accountID(query.get_accountID().clone());
+                }
+
+                Response_oneof_response::cryptogetAccountBalance(data)
+            }
+        });
+
+        response
+    }
+}
+
+// An empty receipt response with only a header, which is all a failed query reads
+fn with_header(header: ResponseHeader) -> TransactionGetReceiptResponse {
+    let mut response = TransactionGetReceiptResponse::new();
+    response.set_header(header);
+    response
+}
+
+// Answer a query whose programmed answer could not be encoded
+fn failed(error: &Error) -> Response {
+    log::debug!(target: "hedera::mock", "failed to encode answer: {}", error);
+
+    let mut header = ResponseHeader::new();
+    header.set_nodeTransactionPrecheckCode(Status::Unknown.into());
+
+    let mut response = Response::new();
+    response.response = Some(Response_oneof_response::transactionGetReceipt(with_header(
+        header,
+    )));
+
+    response
+}
+
+fn query_header(query: &Query) -> &QueryHeader {
+    use self::Query_oneof_query::*;
+
+    match &query.query {
+        Some(getByKey(query)) => query.get_header(),
+        Some(getBySolidityID(query)) => query.get_header(),
+        Some(contractCallLocal(query)) => query.get_header(),
+        Some(contractGetInfo(query)) => query.get_header(),
+        Some(contractGetBytecode(query)) => query.get_header(),
+        Some(ContractGetRecords(query)) => query.get_header(),
+        Some(cryptogetAccountBalance(query)) => query.get_header(),
+        Some(cryptoGetAccountRecords(query)) => query.get_header(),
+        Some(cryptoGetInfo(query)) => query.get_header(),
+        Some(cryptoGetClaim(query)) => query.get_header(),
+        Some(cryptoGetProxyStakers(query)) => query.get_header(),
+        Some(fileGetContents(query)) => query.get_header(),
+        Some(fileGetInfo(query)) => query.get_header(),
+        Some(transactionGetReceipt(query)) => query.get_header(),
+        Some(transactionGetRecord(query)) => query.get_header(),
+        Some(transactionGetFastRecord(query)) => query.get_header(),
+        Some(networkGetVersionInfo(query)) => query.get_header(),
+        Some(scheduleGetInfo(query)) => query.get_header(),
+
+        None => QueryHeader::default_instance(),
+    }
+}
+
+// Implement a service with every transaction and query answered by the mock
+macro_rules! mock_service {
+    ($service:ident { $($transaction:ident),* $(,)? } { $($query:ident),* $(,)? }) => {
+        impl $service for MockService {
+            $(
+                fn $transaction(
+                    &self,
+                    _: grpc::RequestOptions,
+                    transaction: Transaction,
+                ) -> grpc::SingleResponse<TransactionResponse> {
+                    grpc::SingleResponse::completed(self.transaction(transaction))
+                }
+            )*
+
+            $(
+                fn $query(
+                    &self,
+                    _: grpc::RequestOptions,
+                    query: Query,
+                ) -> grpc::SingleResponse<Response> {
+                    grpc::SingleResponse::completed(self.query(query))
+                }
+            )*
+        }
+    };
+}
+
+mock_service!(CryptoService {
+    create_account,
+    update_account,
+    crypto_transfer,
+    crypto_delete,
+    add_claim,
+    delete_claim,
+} {
+    get_claim,
+    get_account_records,
+    crypto_get_balance,
+    get_account_info,
+    get_transaction_receipts,
+    get_fast_transaction_record,
+    get_tx_record_by_tx_id,
+    get_stakers_by_account_id,
+});
+
+mock_service!(FileService {
+    create_file,
+    update_file,
+    delete_file,
+    append_content,
+    system_delete,
+    system_undelete,
+} {
+    get_file_content,
+    get_file_info,
+});
+
+mock_service!(SmartContractService {
+    create_contract,
+    update_contract,
+    contract_call_method,
+    delete_contract,
+    system_delete,
+    system_undelete,
+    call_ethereum,
+} {
+    get_contract_info,
+    contract_call_local_method,
+    contract_get_bytecode,
+    get_by_solidity_id,
+    get_tx_record_by_contract_id,
+});
+
+mock_service!(ScheduleService {
+    create_schedule,
+    delete_schedule,
+    sign_schedule,
+} {
+    get_schedule_info,
+});
+
+mock_service!(FreezeService { freeze } {});
+
+mock_service!(AddressBookService { create_node, delete_node, update_node } {});
+
+mock_service!(UtilService { prng, atomic_batch } {});
+
+mock_service!(NetworkService {} { get_version_info });
+
+#[cfg(test)]
+mod tests {
+    use super::MockNetwork;
+    use crate::{
+        proto,
+        transaction::{Transaction, TransactionCryptoTransfer},
+        Client, ErrorKind, SecretKey, Status,
+    };
+    use failure::Error;
+    use protobuf::Message;
+    use std::sync::Arc;
+
+    fn client(network: &MockNetwork) -> Result<Client, Error> {
+        let mut client = network.client()?;
+        let (secret, _) = SecretKey::generate("");
+
+        client.operator = Some("0:0:2".parse()?);
+        client.operator_secret = Some(Arc::new(move || Ok(secret.clone())));
+
+        Ok(client)
+    }
+
+    // A transfer of 10 tinybars to the operator, so the operator only signs as the payer
+    fn transfer(client: &Client) -> Result<Transaction<TransactionCryptoTransfer>, Error> {
+        let mut transaction = client.transfer_crypto();
+        transaction
+            .transfer("0:0:1001".parse()?, -10)
+            .transfer("0:0:2".parse()?, 10);
+
+        Ok(transaction)
+    }
+
+    #[test]
+    fn test_transfer_and_receipt() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+
+        let response = transfer(&client)?.execute()?;
+
+        let receipt = response.get_receipt(&client)?;
+
+        assert_eq!(receipt.status, Status::Success);
+        assert_eq!(network.submitted().len(), 1);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_programmed_answers() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+
+        network.push_transaction(Status::InsufficientPayerBalance);
+        network.push_balance(42);
+
+        let error = transfer(&client)?.execute().unwrap_err();
+
+        match error.downcast_ref::<ErrorKind>() {
+            Some(ErrorKind::PreCheck { status, .. }) => {
+                assert_eq!(*status, Status::InsufficientPayerBalance)
+            }
+
+            _ => panic!("unexpected error: {}", error),
+        }
+
+        let balance = client.account("0:0:1001".parse()?).balance().get()?;
+        assert_eq!(balance, 42);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_body_bytes() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+
+        let body_bytes = transfer(&client)?.freeze().body_bytes()?;
+
+        let mut tx = proto::Transaction::Transaction::new();
+        tx.set_bodyBytes(body_bytes.clone());
+
+        let mut transaction = Transaction::from_bytes(&client, &tx.write_to_bytes()?)?;
+        assert_eq!(transaction.body_bytes()?, body_bytes);
+
+        transaction.execute()?;
+
+        let submitted: proto::Transaction::Transaction =
+            protobuf::parse_from_bytes(&network.submitted()[0])?;
+
+        assert_eq!(submitted.get_bodyBytes(), &body_bytes[..]);
+        assert!(!submitted.get_sigs().sigs.is_empty());
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_signatures_round_trip() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+        let (secret, _) = SecretKey::generate("");
+
+        let bytes = transfer(&client)?.sign(&secret).to_bytes()?;
+
+        let transaction = Transaction::from_bytes(&client, &bytes)?;
+        let signatures = transaction.signatures()?;
+
+        assert_eq!(signatures.len(), 1);
+        assert_eq!(signatures[0].0, secret.public());
+        assert!(secret.public().verify(transaction.body_bytes()?, &signatures[0].1)?);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_sign_with_operator_first() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+        let (secret, _) = SecretKey::generate("");
+        let operator = (client.operator_secret.as_ref().unwrap())()?;
+
+        let mut transaction = transfer(&client)?;
+        transaction.sign(&secret).sign_with_operator(&client)?;
+
+        let signatures = transaction.build().signatures()?;
+
+        assert_eq!(signatures.len(), 2);
+        assert_eq!(signatures[0].0, operator.public());
+        assert_eq!(signatures[1].0, secret.public());
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_default_retry_predicate() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+
+        network.push_transaction(Status::Busy);
+
+        transfer(&client)?.execute()?;
+
+        assert_eq!(network.submitted().len(), 2);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_custom_retry_predicate() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let mut client = client(&network)?;
+
+        client.set_retry_predicate(|status| status == Status::PlatformNotActive);
+        network.push_transaction(Status::Busy);
+
+        let error = transfer(&client)?.execute().unwrap_err();
+
+        match error.downcast_ref::<ErrorKind>() {
+            Some(ErrorKind::PreCheck { status, .. }) => assert_eq!(*status, Status::Busy),
+            _ => panic!("unexpected error: {}", error),
+        }
+
+        assert_eq!(network.submitted().len(), 1);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_getters() -> Result<(), Error> {
+        let network = MockNetwork::start()?;
+        let client = client(&network)?;
+        let id = "0:0:1234".parse()?;
+
+        let mut append = client.append_file(id, vec![1, 2, 3]);
+        let transaction = append.freeze();
+
+        assert_eq!(transaction.file_id()?, Some(id));
+        assert_eq!(transaction.file_contents()?, Some(vec![1, 2, 3]));
+        assert_eq!(transaction.contract_id()?, None);
+
+        transaction.execute()?;
+
+        // the transaction was taken by `execute`
+        assert!(transaction.file_id().is_err());
+
+        Ok(())
+    }
+}
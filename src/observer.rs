@@ -0,0 +1,136 @@
+use crate::{AccountId, Status, TransactionId};
+use failure::Error;
+use std::{fmt, time::Duration};
+
+/// Whether a request sent to a node was a transaction or a query.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum RequestKind {
+    Transaction,
+    Query,
+}
+
+/// One attempt at sending a request to a node, as reported to an [`Observer`].
+///
+/// A request that is retried, such as a query to a busy node, reports each attempt.
+///
+/// [`Observer`]: trait.Observer.html
+#[derive(Debug, Clone)]
+pub struct RequestAttempt {
+    pub kind: RequestKind,
+    /// The node the request was sent to, if known.
+    pub node: Option<AccountId>,
+    /// The ID of the transaction, or of the payment of a query.
+    pub transaction_id: Option<TransactionId>,
+    /// The number of this attempt, starting at 1.
+    pub attempt: usize,
+    /// The precheck status the node answered with, or `None` if there was no answer.
+    pub status: Option<Status>,
+    /// How long the node took to answer.
+    pub latency: Duration,
+}
+
+/// Whether a message was sent to a node or received from it.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum Direction {
+    Sent,
+    Received,
+}
+
+/// A protobuf message exactly as it was sent to or received from a node, as reported to an
+/// [`Observer`].
+///
+/// Nothing is encoded unless asked for, so looking at only some messages costs nothing for
+/// the rest. The `Debug` format prints every field of the message.
+///
+/// [`Observer`]: trait.Observer.html
+pub struct WireMessage<'a> {
+    pub kind: RequestKind,
+    pub direction: Direction,
+    message: &'a dyn protobuf::Message,
+}
+
+impl<'a> WireMessage<'a> {
+    pub(crate) fn new(
+        kind: RequestKind,
+        direction: Direction,
+        message: &'a dyn protobuf::Message,
+    ) -> Self {
+        Self {
+            kind,
+            direction,
+            message,
+        }
+    }
+
+    /// The encoded bytes of the message, as they went over the wire.
+    pub fn to_bytes(&self) -> Result<Vec<u8>, Error> {
+        Ok(self.message.write_to_bytes()?)
+    }
+
+    /// The full name of the protobuf type of the message, e.g. `proto.Transaction`.
+    pub fn type_name(&self) -> &'static str {
+        self.message.descriptor().full_name()
+    }
+}
+
+impl fmt::Debug for WireMessage<'_> {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        f.debug_struct("WireMessage")
+            .field("kind", &self.kind)
+            .field("direction", &self.direction)
+            .field("message", &self.message)
+            .finish()
+    }
+}
+
+/// Hooks called as the client talks to the network, for example to record spans and metrics.
+///
+/// Any `Fn(&RequestAttempt)` is an observer that only looks at attempts.
+pub trait Observer: Send + Sync {
+    /// Called after every attempt at a transaction or query, successful or not.
+    fn on_attempt(&self, _attempt: &RequestAttempt) {}
+
+    /// Called with every message sent to or received from a node, e.g. to keep the exact
+    /// bytes of a request that a node answered unexpectedly.
+    fn on_message(&self, _message: &WireMessage<'_>) {}
+}
+
+impl<F> Observer for F
+where
+    F: Fn(&RequestAttempt) + Send + Sync,
+{
+    fn on_attempt(&self, attempt: &RequestAttempt) {
+        self(attempt)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::{Observer, RequestAttempt, RequestKind};
+    use std::{
+        sync::atomic::{AtomicUsize, Ordering},
+        time::Duration,
+    };
+
+    #[test]
+    fn test_closure_observer() {
+        let attempts = AtomicUsize::new(0);
+        let observer = |attempt: &RequestAttempt| {
+            attempts.fetch_add(attempt.attempt, Ordering::SeqCst);
+        };
+
+        let attempt = RequestAttempt {
+            kind: RequestKind::Query,
+            node: None,
+            transaction_id: None,
+            attempt: 2,
+            status: None,
+            latency: Duration::from_millis(10),
+        };
+
+        observer.on_attempt(&attempt);
+        observer.on_attempt(&attempt);
+
+        assert_eq!(attempts.load(Ordering::SeqCst), 4);
+    }
+}
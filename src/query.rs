@@ -9,6 +9,8 @@ mod query_crypto_get_info;
 mod query_file_get_contents;
 mod query_file_get_info;
 mod query_get_by_key;
+mod query_network_get_version_info;
+mod query_schedule_get_info;
 mod query_transaction_get_receipt;
 mod query_transaction_get_record;
 
@@ -16,37 +18,55 @@ pub use self::{
     query_contract_get_bytecode::*, query_contract_get_info::*, query_contract_get_records::*,
     query_contract_call::*, query_crypto_get_account_balance::*, query_crypto_get_account_records::*,
     query_crypto_get_claim::*, query_crypto_get_info::*, query_file_get_contents::*,
-    query_file_get_info::*, query_get_by_key::*, query_transaction_get_receipt::*,
-    query_transaction_get_record::*,
+    query_file_get_info::*, query_get_by_key::*, query_network_get_version_info::*,
+    query_schedule_get_info::*, query_transaction_get_receipt::*, query_transaction_get_record::*,
 };
 
 use crate::{
+    grpc_web::GrpcWebClient,
     proto::{
         self,
+        AddressBookService_grpc::AddressBookServiceClient,
         CryptoService_grpc::{CryptoService, CryptoServiceClient},
         FileService_grpc::{FileService, FileServiceClient},
+        FreezeService_grpc::FreezeServiceClient,
+        NetworkService_grpc::{NetworkService, NetworkServiceClient},
         Query::Query_oneof_query,
-        QueryHeader::QueryHeader,
+        QueryHeader::{QueryHeader, ResponseType},
+        ScheduleService_grpc::{ScheduleService, ScheduleServiceClient},
         SmartContractService_grpc::{SmartContractService, SmartContractServiceClient},
         ToProto,
+        UtilService_grpc::UtilServiceClient,
     },
     transaction::{Transaction, TransactionCryptoTransfer},
-    AccountId, Client, ErrorKind, SecretKey, Status,
+    AccountId, Client, Direction, ErrorKind, Observer, RequestAttempt, RequestKind, SecretKey,
+    Status, TransactionId, WireMessage,
 };
 use failure::Error;
 use futures::compat::Compat01As03;
 use futures::{Future};
 use std::{
+    any::Any,
     marker::PhantomData,
     sync::{
         atomic::{AtomicUsize, Ordering},
         Arc,
     },
-    thread::sleep,
-    time::Duration,
+    time::{Duration, Instant},
 };
 
-pub(crate) trait ToQueryProto {
+// Lets `Query::inner` downcast the boxed query back to its concrete type
+pub(crate) trait AsAny {
+    fn as_any_mut(&mut self) -> &mut dyn Any;
+}
+
+impl<T: Any> AsAny for T {
+    fn as_any_mut(&mut self) -> &mut dyn Any {
+        self
+    }
+}
+
+pub(crate) trait ToQueryProto: AsAny {
     fn is_free(&self) -> bool {
         false
     }
@@ -75,10 +95,20 @@ where
     crypto_service: Arc<CryptoServiceClient>,
     contract_service: Arc<SmartContractServiceClient>,
     file_service: Arc<FileServiceClient>,
+    schedule_service: Arc<ScheduleServiceClient>,
+    freeze_service: Arc<FreezeServiceClient>,
+    address_book_service: Arc<AddressBookServiceClient>,
+    util_service: Arc<UtilServiceClient>,
+    network_service: Arc<NetworkServiceClient>,
     payment: Option<proto::Transaction::Transaction>,
+    max_payment: Option<u64>,
+    payment_amount: Option<u64>,
     secret: Option<Arc<dyn Fn() -> Result<SecretKey, Error> + Send + Sync>>,
     operator: Option<AccountId>,
     node: Option<AccountId>,
+    observer: Option<Arc<dyn Observer>>,
+    retryable: Arc<dyn Fn(Status) -> bool + Send + Sync>,
+    web: Option<Arc<GrpcWebClient>>,
     inner: Box<dyn ToQueryProto + Send + Sync>,
     phantom: PhantomData<T>,
 }
@@ -93,17 +123,29 @@ where
     {
         Self {
             payment: None,
+            max_payment: client.max_query_payment,
+            payment_amount: None,
             crypto_service: client.crypto.clone(),
             contract_service: client.contract.clone(),
             file_service: client.file.clone(),
+            schedule_service: client.schedule.clone(),
+            freeze_service: client.freeze.clone(),
+            address_book_service: client.address_book.clone(),
+            util_service: client.util.clone(),
+            network_service: client.network.clone(),
             node: client.node,
             operator: client.operator,
             secret: client.operator_secret.clone(),
+            observer: client.observer.clone(),
+            retryable: client.retryable.clone(),
+            web: client.web.clone(),
             inner: Box::new(inner),
             phantom: PhantomData,
         }
     }
 
+    /// Pay for this query with a prepared transfer to the node, which may be from any account
+    /// and signed by its owner.
     pub fn payment<S: 'static>(
         &mut self,
         transaction: &mut Transaction<TransactionCryptoTransfer, S>,
@@ -112,8 +154,75 @@ where
         Ok(self)
     }
 
+    /// Pay for this query from `payer` instead of the operator of the client.
+    pub fn payer(&mut self, payer: AccountId, secret: SecretKey) -> &mut Self {
+        self.operator = Some(payer);
+        self.secret = Some(Arc::new(move || Ok(secret.clone())));
+        self
+    }
+
+    /// Pay exactly `amount` tinybars for this query instead of asking the node for its cost.
+    ///
+    /// The node keeps any amount over the cost; it rejects the query if the amount is less.
+    pub fn payment_amount(&mut self, amount: u64) -> &mut Self {
+        self.payment_amount = Some(amount);
+        self
+    }
+
+    /// The most this query may cost, in tinybars. If the node asks for more, the query fails
+    /// with `ErrorKind::MaxQueryPaymentExceeded` without paying anything.
+    ///
+    /// Does not apply to queries given an explicit `payment`.
+    pub fn max_payment(&mut self, amount: u64) -> &mut Self {
+        self.max_payment = Some(amount);
+        self
+    }
+
+    /// Ask the node how much answering this query would cost, in tinybars.
+    ///
+    /// The cost is not charged, but nodes still require a payment transaction to be attached;
+    /// if none was given, a transfer of zero from the operator is used.
+    pub async fn cost_async(&mut self) -> Result<u64, Error> {
+        if self.inner.is_free() {
+            return Ok(0);
+        }
+
+        let payment = match &self.payment {
+            Some(payment) => Some(payment.clone()),
+            None => self.payment_transaction(0)?,
+        };
+
+        let query = self.to_query(ResponseType::COST_ANSWER, payment.as_ref());
+        let (header, _) = self.send(query).await?;
+
+        Ok(header.get_cost())
+    }
+
+    pub fn cost(&mut self) -> Result<u64, Error> {
+        crate::RUNTIME.lock().block_on(self.cost_async())
+    }
+
     pub async fn get_async(&mut self) -> Result<T::Response, Error> {
-        T::get(self.send().await?.1)
+        if !self.inner.is_free() && self.payment.is_none() {
+            // Attach a payment transaction for exactly what the query costs if this is a
+            // non-free query and we have payment details
+            let cost = match self.payment_amount {
+                Some(amount) => amount,
+                None => self.cost_async().await?,
+            };
+
+            if let Some(max) = self.max_payment {
+                if cost > max {
+                    Err(ErrorKind::MaxQueryPaymentExceeded { cost, max })?;
+                }
+            }
+
+            self.payment = self.payment_transaction(cost)?;
+        }
+
+        let query = self.to_proto();
+
+        T::get(self.send(query).await?.1)
     }
 
     pub fn get(&mut self) -> Result<T::Response, Error> {
@@ -122,8 +231,84 @@ where
             .block_on(self.get_async())
     }
 
+    #[inline]
+    pub(crate) fn inner(&mut self) -> &mut T {
+        // through the box, so the call goes to the boxed query and not the box itself
+        match (*self.inner).as_any_mut().downcast_mut() {
+            Some(inner) => inner,
+
+            // not possible in safe rust to get here; `inner` is always a `T`
+            None => unreachable!(),
+        }
+    }
+
+    // Build a transfer of `amount` from the operator to the node, if we have payment details
+    fn payment_transaction(
+        &self,
+        amount: u64,
+    ) -> Result<Option<proto::Transaction::Transaction>, Error> {
+        let (node, operator) = match (self.node, self.operator, &self.secret) {
+            (Some(node), Some(operator), Some(_)) => (node, operator),
+            _ => return Ok(None),
+        };
+
+        let client = Client {
+            node: self.node,
+            operator: self.operator,
+            operator_secret: self.secret.clone(),
+            crypto: self.crypto_service.clone(),
+            file: self.file_service.clone(),
+            contract: self.contract_service.clone(),
+            schedule: self.schedule_service.clone(),
+            freeze: self.freeze_service.clone(),
+            address_book: self.address_book_service.clone(),
+            util: self.util_service.clone(),
+            network: self.network_service.clone(),
+            mirror: None,
+            mirror_network: None,
+            mirror_network_address: None,
+            regenerate_transaction_id: true,
+            max_query_payment: None,
+            mirror_receipt_fallback: false,
+            observer: None,
+            retryable: Arc::new(crate::client::is_retryable),
+            web: None,
+        };
+
+        let tx = TransactionCryptoTransfer::new(&client)
+            .transfer(node, amount as i64)
+            .transfer(operator, -(amount as i64))
+            .build()
+            .take_raw()?
+            .tx;
+
+        Ok(Some(tx))
+    }
+
+    fn to_query(
+        &self,
+        response_type: ResponseType,
+        payment: Option<&proto::Transaction::Transaction>,
+    ) -> Result<proto::Query::Query, Error> {
+        let mut header = proto::QueryHeader::QueryHeader::new();
+
+        header.set_responseType(response_type);
+
+        if let Some(payment) = payment {
+            header.set_payment(payment.clone());
+        } else if !self.inner.is_free() {
+            return Err(ErrorKind::MissingField("payment"))?;
+        }
+
+        let mut query = proto::Query::Query::new();
+        query.query = Some(self.inner.to_query_proto(header)?);
+
+        Ok(query)
+    }
+
     fn send(
-        &mut self,
+        &self,
+        query: Result<proto::Query::Query, Error>,
     ) -> impl Future<
         Output = Result<
             (
@@ -135,77 +320,138 @@ where
     > {
         use self::proto::Query::Query_oneof_query::*;
 
-        if !self.inner.is_free() && self.payment.is_none() {
-            // Attach a payment transaction if this is a non-free query and we
-            // have payment details
-            if self.operator.is_some() && self.node.is_some() && self.secret.is_some() {
-                let cost = 100_300_000;
-                self.payment = TransactionCryptoTransfer::new(&Client {
-                    node: self.node.clone(),
-                    operator: self.operator.clone(),
-                    operator_secret: self.secret.clone(),
-                    crypto: self.crypto_service.clone(),
-                    file: self.file_service.clone(),
-                    contract: self.contract_service.clone(),
-                })
-                .transfer(*self.node.as_ref().unwrap(), cost as i64)
-                .transfer(*self.operator.as_ref().unwrap(), -(cost as i64))
-                .build()
-                .take_raw()
-                .ok()
-                .map(|tx| tx.tx);
-            }
-        }
-
         let attempt = AtomicUsize::new(0);
         let crypto = self.crypto_service.clone();
         let file = self.file_service.clone();
         let contract = self.contract_service.clone();
-        let query_res: Option<Result<proto::Query::Query, _>> = Some(self.to_proto());
+        let schedule = self.schedule_service.clone();
+        let network = self.network_service.clone();
+        let observer = self.observer.clone();
+        let retryable = self.retryable.clone();
+        let web = self.web.clone();
+        let node = self.node;
+        let query_res: Option<Result<proto::Query::Query, _>> = Some(query);
+
+        let payment_transaction_id: Option<TransactionId> = self
+            .payment
+            .as_ref()
+            .map(|payment| payment.get_body().get_transactionID().clone().into());
 
         async move {
             #[allow(clippy::never_loop)]
             loop {
                 break if let Some(Ok(query)) = &query_res {
                     if attempt.load(Ordering::SeqCst) == 0 {
-                        log::trace!("sent: {:#?}", query);
+                        log::trace!(target: "hedera::query", "sent: {:#?}", query);
+                    }
+
+                    if let Some(observer) = &observer {
+                        observer.on_message(&WireMessage::new(
+                            RequestKind::Query,
+                            Direction::Sent,
+                            query,
+                        ));
                     }
 
                     let query = query.clone();
-                    let o = grpc::RequestOptions::default();
-                    let response = match query.query {
-                        //////////////////////// CRYPTO QUERIES
-                        Some(cryptogetAccountBalance(_)) => crypto.crypto_get_balance(o, query),
-                        Some(cryptoGetInfo(_)) => crypto.get_account_info(o, query),
-                        Some(cryptoGetAccountRecords(_)) => crypto.get_account_records(o, query),
-                        //////////////////////// FILE QUERIES
-                        Some(fileGetInfo(_)) => file.get_file_info(o, query),
-                        Some(fileGetContents(_)) => file.get_file_content(o, query),
-                        //////////////////////// TRANSACTION QUERIES
-                        Some(transactionGetRecord(_)) => crypto.get_tx_record_by_tx_id(o, query),
-                        Some(transactionGetReceipt(_)) => crypto.get_transaction_receipts(o, query),
-                        //////////////////////// CONTRACT QUERIES
-                        Some(contractGetInfo(_)) => contract.get_contract_info(o, query),
-                        Some(contractGetBytecode(_)) => contract.contract_get_bytecode(o, query),
-                        Some(contractCallLocal(_)) => contract.contract_call_local_method(o, query),
-
-                        _ => unreachable!(),
+                    let start = Instant::now();
+                    let report = |status: Option<Status>| {
+                        if let Some(observer) = &observer {
+                            observer.on_attempt(&RequestAttempt {
+                                kind: RequestKind::Query,
+                                node,
+                                transaction_id: payment_transaction_id.clone(),
+                                attempt: attempt.load(Ordering::SeqCst) + 1,
+                                status,
+                                latency: start.elapsed(),
+                            });
+                        }
+                    };
+
+                    let response = if let Some(web) = &web {
+                        web.unary(query_method(&query), &query).await
+                    } else {
+                        let o = grpc::RequestOptions::default();
+                        let response = match query.query {
+                            //////////////////////// CRYPTO QUERIES
+                            Some(cryptogetAccountBalance(_)) => crypto.crypto_get_balance(o, query),
+                            Some(cryptoGetInfo(_)) => crypto.get_account_info(o, query),
+                            Some(cryptoGetAccountRecords(_)) => {
+                                crypto.get_account_records(o, query)
+                            }
+                            //////////////////////// FILE QUERIES
+                            Some(fileGetInfo(_)) => file.get_file_info(o, query),
+                            Some(fileGetContents(_)) => file.get_file_content(o, query),
+                            //////////////////////// TRANSACTION QUERIES
+                            Some(transactionGetRecord(_)) => {
+                                crypto.get_tx_record_by_tx_id(o, query)
+                            }
+                            Some(transactionGetReceipt(_)) => {
+                                crypto.get_transaction_receipts(o, query)
+                            }
+                            //////////////////////// CONTRACT QUERIES
+                            Some(contractGetInfo(_)) => contract.get_contract_info(o, query),
+                            Some(contractGetBytecode(_)) => {
+                                contract.contract_get_bytecode(o, query)
+                            }
+                            Some(contractCallLocal(_)) => {
+                                contract.contract_call_local_method(o, query)
+                            }
+                            //////////////////////// NETWORK QUERIES
+                            Some(networkGetVersionInfo(_)) => network.get_version_info(o, query),
+                            //////////////////////// SCHEDULE QUERIES
+                            Some(scheduleGetInfo(_)) => schedule.get_schedule_info(o, query),
+
+                            _ => unreachable!(),
+                        };
+
+                        Compat01As03::new(response.drop_metadata()).await.map_err(Error::from)
                     };
 
-                    let mut response = Compat01As03::new(response.drop_metadata()).await?;
-                    log::trace!("recv: {:#?}", response);
+                    let mut response = response.map_err(|error| {
+                        report(None);
+                        error
+                    })?;
+
+                    log::trace!(target: "hedera::query", "recv: {:#?}", response);
+
+                    if let Some(observer) = &observer {
+                        observer.on_message(&WireMessage::new(
+                            RequestKind::Query,
+                            Direction::Received,
+                            &response,
+                        ));
+                    }
 
                     let header = take_header(&mut response);
-                    match header.get_nodeTransactionPrecheckCode().into() {
-                        Status::Busy if attempt.load(Ordering::SeqCst) < 5 => {
+                    let status: Status = header.get_nodeTransactionPrecheckCode().into();
+                    report(Some(status));
+
+                    match status {
+                        Status::Ok => Ok((header, response)),
+
+                        status if attempt.load(Ordering::SeqCst) < 5 && retryable(status) => {
                             let attempt = attempt.fetch_add(1, Ordering::SeqCst) + 1;
-                            sleep(Duration::from_secs((attempt * 2) as u64));
+                            log::debug!(
+                                target: "hedera::query",
+                                "{:?}; retry {} of 5",
+                                status,
+                                attempt
+                            );
+
+                            let delay = Duration::from_secs((attempt * 2) as u64);
+                            tokio::timer::delay(Instant::now() + delay).await;
                             continue;
                         }
 
-                        Status::Ok => Ok((header, response)),
+                        status => {
+                            log::debug!(target: "hedera::query", "query failed: {:?}", status);
 
-                        pre_check_code => Err(ErrorKind::PreCheck(pre_check_code))?,
+                            Err(ErrorKind::QueryStatus {
+                                status,
+                                payment_transaction_id: payment_transaction_id.clone(),
+                            })?
+                        }
                     }
                 } else if let Some(Err(error)) = query_res {
                     Err(error)
@@ -222,20 +468,36 @@ where
     T: QueryResponse + Send + Sync + 'static,
 {
     fn to_proto(&self) -> Result<proto::Query::Query, Error> {
-        let mut header = proto::QueryHeader::QueryHeader::new();
-
-        header.set_responseType(proto::QueryHeader::ResponseType::ANSWER_ONLY);
+        self.to_query(ResponseType::ANSWER_ONLY, self.payment.as_ref())
+    }
+}
 
-        if let Some(payment) = &self.payment {
-            header.set_payment(payment.clone());
-        } else if !self.inner.is_free() {
-            return Err(ErrorKind::MissingField("payment"))?;
-        }
+// The path of the gRPC method a query is sent to, for transports that call methods by path;
+// this must agree with the services used in `send`
+fn query_method(query: &proto::Query::Query) -> &'static str {
+    use self::proto::Query::Query_oneof_query::*;
 
-        let mut query = proto::Query::Query::new();
-        query.query = Some(self.inner.to_query_proto(header)?);
+    match &query.query {
+        //////////////////////// CRYPTO QUERIES
+        Some(cryptogetAccountBalance(_)) => "/proto.CryptoService/cryptoGetBalance",
+        Some(cryptoGetInfo(_)) => "/proto.CryptoService/getAccountInfo",
+        Some(cryptoGetAccountRecords(_)) => "/proto.CryptoService/getAccountRecords",
+        //////////////////////// FILE QUERIES
+        Some(fileGetInfo(_)) => "/proto.FileService/getFileInfo",
+        Some(fileGetContents(_)) => "/proto.FileService/getFileContent",
+        //////////////////////// TRANSACTION QUERIES
+        Some(transactionGetRecord(_)) => "/proto.CryptoService/getTxRecordByTxID",
+        Some(transactionGetReceipt(_)) => "/proto.CryptoService/getTransactionReceipts",
+        //////////////////////// CONTRACT QUERIES
+        Some(contractGetInfo(_)) => "/proto.SmartContractService/getContractInfo",
+        Some(contractGetBytecode(_)) => "/proto.SmartContractService/ContractGetBytecode",
+        Some(contractCallLocal(_)) => "/proto.SmartContractService/contractCallLocalMethod",
+        //////////////////////// NETWORK QUERIES
+        Some(networkGetVersionInfo(_)) => "/proto.NetworkService/getVersionInfo",
+        //////////////////////// SCHEDULE QUERIES
+        Some(scheduleGetInfo(_)) => "/proto.ScheduleService/getScheduleInfo",
 
-        Ok(query)
+        _ => unreachable!(),
     }
 }
 
@@ -263,6 +525,8 @@ pub(crate) fn take_header(
         Some(transactionGetReceipt(ref mut res)) => res.take_header(),
         Some(transactionGetRecord(ref mut res)) => res.take_header(),
         Some(transactionGetFastRecord(ref mut res)) => res.take_header(),
+        Some(networkGetVersionInfo(ref mut res)) => res.take_header(),
+        Some(scheduleGetInfo(ref mut res)) => res.take_header(),
 
         None => unreachable!(),
     }
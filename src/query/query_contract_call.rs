@@ -1,28 +1,70 @@
 use crate::{
+    call_params::CallParams,
+    function_result::ContractFunctionResult,
     proto::{self, Query::Query_oneof_query, QueryHeader::QueryHeader, ToProto},
     query::{Query, QueryResponse, ToQueryProto},
-    Client, ContractId, function_result::ContractFunctionResult
+    AccountId, Client, ContractId,
 };
 use failure::Error;
 
+/// Call a function of a smart contract instance locally on the node. This cannot change the
+/// state of the contract and does not reach consensus; it is useful for calling getters.
 pub struct QueryContractCall {
     contract_id: ContractId,
     gas: i64,
     function_parameters: Vec<u8>,
-    max_result_size: i64
+    max_result_size: i64,
+    sender: Option<AccountId>,
 }
 
 impl QueryContractCall {
-    pub fn new(client: &Client, contract_id: ContractId, gas: i64,
-    function_parameters: Vec<u8>, max_result_size: i64) -> Query<Self> {
+    pub fn new(client: &Client, contract_id: ContractId) -> Query<Self> {
         Query::new(
             client,
             Self {
-            contract_id,
-            gas,
-            function_parameters,
-            max_result_size
-        })
+                contract_id,
+                gas: 0,
+                function_parameters: Vec::new(),
+                max_result_size: 0,
+                sender: None,
+            },
+        )
+    }
+}
+
+impl Query<QueryContractCall> {
+    /// Sets the amount of gas to use for the call. All of the gas offered will be charged for.
+    #[inline]
+    pub fn gas(&mut self, gas: i64) -> &mut Self {
+        self.inner().gas = gas;
+        self
+    }
+
+    #[inline]
+    pub fn function_parameters(&mut self, parameters: Vec<u8>) -> &mut Self {
+        self.inner().function_parameters = parameters;
+        self
+    }
+
+    #[inline]
+    pub fn call_params(&mut self, params: &CallParams) -> &mut Self {
+        self.function_parameters(params.assemble())
+    }
+
+    /// Sets the maximum number of bytes that the result might include. The call will fail
+    /// if it would have returned more than this number of bytes.
+    #[inline]
+    pub fn max_result_size(&mut self, size: i64) -> &mut Self {
+        self.inner().max_result_size = size;
+        self
+    }
+
+    /// Sets the account that is the "sender" of the call. Defaults to the operator paying
+    /// for the query.
+    #[inline]
+    pub fn sender(&mut self, id: AccountId) -> &mut Self {
+        self.inner().sender = Some(id);
+        self
     }
 }
 
@@ -30,7 +72,10 @@ impl QueryResponse for QueryContractCall {
     type Response = ContractFunctionResult;
 
     fn get(mut response: proto::Response::Response) -> Result<Self::Response, Error> {
-        Ok(response.take_contractCallLocal().take_functionResult().into())
+        Ok(response
+            .take_contractCallLocal()
+            .take_functionResult()
+            .into())
     }
 }
 
@@ -43,6 +88,10 @@ impl ToQueryProto for QueryContractCall {
         query.set_functionParameters(self.function_parameters.clone());
         query.set_maxResultSize(self.max_result_size);
 
+        if let Some(sender) = &self.sender {
+            query.set_sender_id(sender.to_proto()?);
+        }
+
         Ok(Query_oneof_query::contractCallLocal(query))
     }
-}
\ No newline at end of file
+}
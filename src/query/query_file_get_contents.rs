@@ -5,6 +5,7 @@ use crate::{
     Client,
 };
 use failure::Error;
+use std::marker::PhantomData;
 use try_from::{TryFrom, TryInto};
 
 impl TryFrom<proto::FileGetContents::FileGetContentsResponse_FileContents> for Vec<u8> {
@@ -48,3 +49,44 @@ impl ToQueryProto for QueryFileGetContents {
         Ok(Query_oneof_query::fileGetContents(query))
     }
 }
+
+/// Get the contents of a file and decode them as `T`.
+///
+/// Used for the system files that hold protobuf encoded network state, such as the
+/// address book, fee schedules, and exchange rates.
+pub struct QueryFileGetContentsAs<T> {
+    file: FileId,
+    phantom: PhantomData<fn() -> T>,
+}
+
+impl<T> QueryFileGetContentsAs<T>
+where
+    T: TryFrom<Vec<u8>, Err = Error> + Send + 'static,
+{
+    pub fn new(client: &Client, file: FileId) -> Query<Self> {
+        Query::new(
+            client,
+            Self {
+                file,
+                phantom: PhantomData,
+            },
+        )
+    }
+}
+
+impl<T> QueryResponse for QueryFileGetContentsAs<T>
+where
+    T: TryFrom<Vec<u8>, Err = Error> + Send + 'static,
+{
+    type Response = T;
+
+    fn get(response: proto::Response::Response) -> Result<Self::Response, Error> {
+        QueryFileGetContents::get(response)?.try_into()
+    }
+}
+
+impl<T: 'static> ToQueryProto for QueryFileGetContentsAs<T> {
+    fn to_query_proto(&self, header: QueryHeader) -> Result<Query_oneof_query, Error> {
+        QueryFileGetContents { file: self.file }.to_query_proto(header)
+    }
+}
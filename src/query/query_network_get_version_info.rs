@@ -0,0 +1,31 @@
+use crate::{
+    proto::{self, Query::Query_oneof_query, QueryHeader::QueryHeader},
+    query::{Query, QueryResponse, ToQueryProto},
+    Client, NetworkVersionInfo,
+};
+use failure::Error;
+
+pub struct QueryNetworkGetVersionInfo;
+
+impl QueryNetworkGetVersionInfo {
+    pub fn new(client: &Client) -> Query<Self> {
+        Query::new(client, Self)
+    }
+}
+
+impl QueryResponse for QueryNetworkGetVersionInfo {
+    type Response = NetworkVersionInfo;
+
+    fn get(mut response: proto::Response::Response) -> Result<Self::Response, Error> {
+        Ok(response.take_networkGetVersionInfo().into())
+    }
+}
+
+impl ToQueryProto for QueryNetworkGetVersionInfo {
+    fn to_query_proto(&self, header: QueryHeader) -> Result<Query_oneof_query, Error> {
+        let mut query = proto::NetworkGetVersionInfo::NetworkGetVersionInfoQuery::new();
+        query.set_header(header);
+
+        Ok(Query_oneof_query::networkGetVersionInfo(query))
+    }
+}
@@ -0,0 +1,35 @@
+use crate::{
+    proto::{self, Query::Query_oneof_query, QueryHeader::QueryHeader, ToProto},
+    query::{Query, QueryResponse, ToQueryProto},
+    Client, ScheduleId, ScheduleInfo,
+};
+use failure::Error;
+use try_from::TryInto;
+
+pub struct QueryScheduleGetInfo {
+    schedule: ScheduleId,
+}
+
+impl QueryScheduleGetInfo {
+    pub fn new(client: &Client, schedule: ScheduleId) -> Query<Self> {
+        Query::new(client, Self { schedule })
+    }
+}
+
+impl QueryResponse for QueryScheduleGetInfo {
+    type Response = ScheduleInfo;
+
+    fn get(mut response: proto::Response::Response) -> Result<Self::Response, Error> {
+        response.take_scheduleGetInfo().take_scheduleInfo().try_into()
+    }
+}
+
+impl ToQueryProto for QueryScheduleGetInfo {
+    fn to_query_proto(&self, header: QueryHeader) -> Result<Query_oneof_query, Error> {
+        let mut query = proto::ScheduleGetInfo::ScheduleGetInfoQuery::new();
+        query.set_header(header);
+        query.set_scheduleID(self.schedule.to_proto()?);
+
+        Ok(Query_oneof_query::scheduleGetInfo(query))
+    }
+}
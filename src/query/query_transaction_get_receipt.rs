@@ -1,17 +1,101 @@
 use crate::{
     proto::{self, Query::Query_oneof_query, QueryHeader::QueryHeader, ToProto},
     query::{Query, QueryResponse, ToQueryProto},
-    Client, TransactionId, TransactionReceipt,
+    Client, ErrorKind, Status, TransactionId, TransactionReceipt,
 };
-use failure::Error;
+use failure::{format_err, Error};
+use std::time::{Duration, Instant};
 
 pub struct QueryTransactionGetReceipt {
     transaction_id: TransactionId,
+    include_children: bool,
+    include_duplicates: bool,
+    timeout: Duration,
 }
 
 impl QueryTransactionGetReceipt {
     pub fn new(client: &Client, transaction_id: TransactionId) -> Query<Self> {
-        Query::new(client, Self { transaction_id })
+        Query::new(
+            client,
+            Self {
+                transaction_id,
+                include_children: false,
+                include_duplicates: false,
+                // a transaction that has not reached consensus after its valid duration
+                // never will
+                timeout: Duration::from_secs(120),
+            },
+        )
+    }
+}
+
+impl Query<QueryTransactionGetReceipt> {
+    /// Also get the receipts of the child transactions the transaction caused.
+    #[inline]
+    pub fn include_children(&mut self, include: bool) -> &mut Self {
+        self.inner().include_children = include;
+        self
+    }
+
+    /// Also get the receipts of the other transactions submitted with the same ID.
+    #[inline]
+    pub fn include_duplicates(&mut self, include: bool) -> &mut Self {
+        self.inner().include_duplicates = include;
+        self
+    }
+
+    /// How long `wait` polls for the transaction to reach consensus. Defaults to 2 minutes.
+    #[inline]
+    pub fn timeout(&mut self, timeout: Duration) -> &mut Self {
+        self.inner().timeout = timeout;
+        self
+    }
+
+    /// Poll for the receipt until the transaction reaches consensus.
+    ///
+    /// Unlike `get`, which returns a receipt with an `Unknown` status if the transaction has
+    /// not reached consensus yet, this only returns the final receipt.
+    pub async fn wait_async(&mut self) -> Result<TransactionReceipt, Error> {
+        let start = Instant::now();
+        let timeout = self.inner().timeout;
+        let mut delay = Duration::from_millis(250);
+
+        loop {
+            let status = match self.get_async().await {
+                Ok(receipt) => {
+                    if receipt.status != Status::Unknown {
+                        break Ok(receipt);
+                    }
+
+                    receipt.status
+                }
+
+                Err(error) => match error.downcast_ref::<ErrorKind>() {
+                    // the node has not heard of the transaction yet
+                    Some(ErrorKind::QueryStatus {
+                        status: Status::ReceiptNotFound,
+                        ..
+                    }) => Status::ReceiptNotFound,
+
+                    _ => break Err(error),
+                },
+            };
+
+            if start.elapsed() + delay > timeout {
+                break Err(format_err!(
+                    "timed out waiting for transaction {} to reach consensus: {:?}",
+                    self.inner().transaction_id,
+                    status
+                ));
+            }
+
+            tokio::timer::delay(Instant::now() + delay).await;
+            delay = (delay * 2).min(Duration::from_secs(4));
+        }
+    }
+
+    pub fn wait(&mut self) -> Result<TransactionReceipt, Error> {
+        crate::RUNTIME.lock().block_on(self.wait_async())
     }
 }
 
@@ -19,7 +103,7 @@ impl QueryResponse for QueryTransactionGetReceipt {
     type Response = TransactionReceipt;
 
     fn get(mut response: proto::Response::Response) -> Result<Self::Response, Error> {
-        Ok(response.take_transactionGetReceipt().take_receipt().into())
+        Ok(response.take_transactionGetReceipt().into())
     }
 }
 
@@ -32,7 +116,32 @@ impl ToQueryProto for QueryTransactionGetReceipt {
         let mut query = proto::TransactionGetReceipt::TransactionGetReceiptQuery::new();
         query.set_header(header);
         query.set_transactionID(self.transaction_id.to_proto()?);
+        query.set_includeDuplicates(self.include_duplicates);
+        query.set_include_child_receipts(self.include_children);
 
         Ok(Query_oneof_query::transactionGetReceipt(query))
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use super::QueryTransactionGetReceipt;
+    use crate::{Client, TransactionId};
+    use failure::Error;
+    use std::time::Duration;
+
+    #[test]
+    fn test_setters() -> Result<(), Error> {
+        let client = Client::new("127.0.0.1:50211")?;
+        let id = TransactionId::new("0:0:2".parse()?);
+
+        let mut query = QueryTransactionGetReceipt::new(&client, id);
+        query.include_children(true).timeout(Duration::from_secs(5));
+
+        assert!(query.inner().include_children);
+        assert!(!query.inner().include_duplicates);
+        assert_eq!(query.inner().timeout, Duration::from_secs(5));
+
+        Ok(())
+    }
+}
@@ -1,18 +1,43 @@
 use crate::{
     proto::{self, Query::Query_oneof_query, QueryHeader::QueryHeader, ToProto},
     query::{Query, QueryResponse, ToQueryProto},
-    Client, TransactionId, TransactionRecord
+    Client, TransactionId, TransactionRecord,
 };
 use failure::Error;
 use try_from::TryInto;
 
 pub struct QueryTransactionGetRecord {
     transaction: TransactionId,
+    include_children: bool,
+    include_duplicates: bool,
 }
 
 impl QueryTransactionGetRecord {
     pub fn new(client: &Client, transaction: TransactionId) -> Query<Self> {
-        Query::new(client, Self { transaction })
+        Query::new(
+            client,
+            Self {
+                transaction,
+                include_children: false,
+                include_duplicates: false,
+            },
+        )
+    }
+}
+
+impl Query<QueryTransactionGetRecord> {
+    /// Also get the records of the child transactions the transaction caused.
+    #[inline]
+    pub fn include_children(&mut self, include: bool) -> &mut Self {
+        self.inner().include_children = include;
+        self
+    }
+
+    /// Also get the records of the other transactions submitted with the same ID.
+    #[inline]
+    pub fn include_duplicates(&mut self, include: bool) -> &mut Self {
+        self.inner().include_duplicates = include;
+        self
     }
 }
 
@@ -20,10 +45,7 @@ impl QueryResponse for QueryTransactionGetRecord {
     type Response = TransactionRecord;
 
     fn get(mut response: proto::Response::Response) -> Result<Self::Response, Error> {
-        response
-            .take_transactionGetRecord()
-            .take_transactionRecord()
-            .try_into()
+        response.take_transactionGetRecord().try_into()
     }
 }
 
@@ -32,6 +54,8 @@ impl ToQueryProto for QueryTransactionGetRecord {
         let mut query = proto::TransactionGetRecord::TransactionGetRecordQuery::new();
         query.set_header(header);
         query.set_transactionID(self.transaction.to_proto()?);
+        query.set_includeDuplicates(self.include_duplicates);
+        query.set_include_child_records(self.include_children);
 
         Ok(Query_oneof_query::transactionGetRecord(query))
     }
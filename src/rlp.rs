@@ -0,0 +1,144 @@
+// Minimal RLP (Recursive Length Prefix) encoding, as used by Ethereum transactions
+use failure::{format_err, Error};
+
+#[derive(Debug, Clone, PartialEq)]
+pub(crate) enum Rlp {
+    Bytes(Vec<u8>),
+    List(Vec<Rlp>),
+}
+
+impl Rlp {
+    /// Decode a single RLP item that spans all of `data`.
+    pub(crate) fn decode(data: &[u8]) -> Result<Self, Error> {
+        let (item, rest) = decode_item(data)?;
+
+        if !rest.is_empty() {
+            Err(format_err!("{} unexpected bytes after RLP item", rest.len()))?;
+        }
+
+        Ok(item)
+    }
+
+    pub(crate) fn encode(&self) -> Vec<u8> {
+        match self {
+            Rlp::Bytes(bytes) if bytes.len() == 1 && bytes[0] < 0x80 => bytes.clone(),
+            Rlp::Bytes(bytes) => {
+                let mut out = encode_len(bytes.len(), 0x80);
+                out.extend(bytes);
+                out
+            }
+
+            Rlp::List(items) => {
+                let payload: Vec<u8> = items.iter().flat_map(Rlp::encode).collect();
+                let mut out = encode_len(payload.len(), 0xc0);
+                out.extend(payload);
+                out
+            }
+        }
+    }
+}
+
+fn encode_len(len: usize, offset: u8) -> Vec<u8> {
+    if len <= 55 {
+        return vec![offset + len as u8];
+    }
+
+    let len_bytes: Vec<u8> = len
+        .to_be_bytes()
+        .iter()
+        .cloned()
+        .skip_while(|b| *b == 0)
+        .collect();
+
+    let mut out = vec![offset + 55 + len_bytes.len() as u8];
+    out.extend(len_bytes);
+    out
+}
+
+// Decode the item at the start of `data`, returning it and the remaining bytes
+fn decode_item(data: &[u8]) -> Result<(Rlp, &[u8]), Error> {
+    let prefix = *data
+        .first()
+        .ok_or_else(|| format_err!("unexpected end of RLP data"))?;
+
+    match prefix {
+        0x00..=0x7f => Ok((Rlp::Bytes(vec![prefix]), &data[1..])),
+
+        0x80..=0xbf => {
+            let (payload, rest) = split_payload(data, 0x80)?;
+            Ok((Rlp::Bytes(payload.to_vec()), rest))
+        }
+
+        0xc0..=0xff => {
+            let (mut payload, rest) = split_payload(data, 0xc0)?;
+            let mut items = Vec::new();
+
+            while !payload.is_empty() {
+                let (item, remaining) = decode_item(payload)?;
+                items.push(item);
+                payload = remaining;
+            }
+
+            Ok((Rlp::List(items), rest))
+        }
+    }
+}
+
+// Split the payload of the string or list at the start of `data` from the bytes after it
+fn split_payload(data: &[u8], offset: u8) -> Result<(&[u8], &[u8]), Error> {
+    let prefix = data[0] - offset;
+
+    let (len, start) = if prefix <= 55 {
+        (prefix as usize, 1)
+    } else {
+        let len_len = (prefix - 55) as usize;
+        let len_bytes = data
+            .get(1..=len_len)
+            .ok_or_else(|| format_err!("unexpected end of RLP data"))?;
+
+        if len_len > 8 {
+            Err(format_err!("RLP item length is too large"))?;
+        }
+
+        let len = len_bytes
+            .iter()
+            .fold(0usize, |len, b| (len << 8) | *b as usize);
+
+        (len, 1 + len_len)
+    };
+
+    let end = start
+        .checked_add(len)
+        .ok_or_else(|| format_err!("RLP item length is too large"))?;
+
+    if data.len() < end {
+        Err(format_err!("unexpected end of RLP data"))?;
+    }
+
+    Ok((&data[start..end], &data[end..]))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_encode_decode() -> Result<(), Error> {
+        let dog = Rlp::Bytes(b"dog".to_vec());
+        let list = Rlp::List(vec![Rlp::Bytes(b"cat".to_vec()), dog.clone()]);
+        let long = Rlp::Bytes(vec![0xaa; 56]);
+
+        assert_eq!(hex::encode(dog.encode()), "83646f67");
+        assert_eq!(hex::encode(list.encode()), "c88363617483646f67");
+        assert_eq!(hex::encode(Rlp::Bytes(vec![]).encode()), "80");
+        assert_eq!(hex::encode(Rlp::Bytes(vec![0x0f]).encode()), "0f");
+        assert_eq!(&long.encode()[..2], &[0xb8, 56]);
+
+        assert_eq!(Rlp::decode(&list.encode())?, list);
+        assert_eq!(Rlp::decode(&long.encode())?, long);
+        assert!(Rlp::decode(&[0x83, 0x64]).is_err());
+        assert!(Rlp::decode(&[0xbf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff]).is_err());
+
+        Ok(())
+    }
+}
@@ -1,9 +1,12 @@
-use crate::proto;
+use crate::{error::ErrorKind, proto};
+use failure::Error;
+use protobuf::ProtobufEnum;
+use std::{fmt, str::FromStr};
 //use crate::status::Status::EmptyClaimHash;
 //use test::TestFn::{StaticBenchFn, StaticTestFn};
 
 #[derive(Debug, Copy, Clone, PartialEq)]
-#[repr(u8)]
+#[repr(u16)]
 pub enum Status {
     // the transaction passed the precheck
     Ok = 0,
@@ -305,6 +308,747 @@ pub enum Status {
     FeeScheduleFilePartUploaded = 104,
 
     ExchangeRateChangeLimitExceeded = 105,
+
+    // contract permanent storage exceeded the currently allowable limit
+    MaxContractStorageExceeded = 106,
+
+    // transfer Account should not be same as Account to be deleted
+    TransferAccountSameAsDeleteAccount = 107,
+
+    // the total balance of the ledger is not the expected amount
+    TotalLedgerBalanceInvalid = 108,
+
+    // the expiration date/time on a smart contract may not be reduced
+    ExpirationReductionNotAllowed = 110,
+
+    // gas exceeded currently allowable gas limit per transaction
+    MaxGasLimitExceeded = 111,
+
+    // file size exceeded the currently allowable limit
+    MaxFileSizeExceeded = 112,
+
+    // when a valid signature is not provided for operations on account with receiverSigRequired=true
+    ReceiverSigRequired = 113,
+
+    // the Topic ID specified is not in the system
+    InvalidTopicId = 150,
+
+    // a provided admin key was invalid
+    InvalidAdminKey = 155,
+
+    // a provided submit key was invalid
+    InvalidSubmitKey = 156,
+
+    // an attempted operation was not authorized (ie - a deleteTopic for a topic with no adminKey)
+    Unauthorized = 157,
+
+    // a ConsensusService message is empty
+    InvalidTopicMessage = 158,
+
+    // the autoRenewAccount specified is not a valid, active account
+    InvalidAutorenewAccount = 159,
+
+    // an adminKey was not specified on the topic, so there must not be an autoRenewAccount
+    AutorenewAccountNotAllowed = 160,
+
+    // the topic has expired, was not automatically renewed, and is in a 7 day grace period before the topic will be deleted unrecoverably
+    TopicExpired = 162,
+
+    // chunk number must be from 1 to total (chunks) inclusive
+    InvalidChunkNumber = 163,
+
+    // for every chunk, the payer account that is part of initialTransactionID must match the Payer Account of this transaction
+    InvalidChunkTransactionId = 164,
+
+    // account is frozen and cannot transact with the token
+    AccountFrozenForToken = 165,
+
+    // an involved account already has more than tokens.maxPerAccount associations with non-deleted tokens
+    TokensPerAccountLimitExceeded = 166,
+
+    // the token is invalid or does not exist
+    InvalidTokenId = 167,
+
+    // invalid token decimals
+    InvalidTokenDecimals = 168,
+
+    // invalid token initial supply
+    InvalidTokenInitialSupply = 169,
+
+    // treasury Account does not exist or is deleted
+    InvalidTreasuryAccountForToken = 170,
+
+    // token Symbol is not UTF-8 capitalized alphabetical string
+    InvalidTokenSymbol = 171,
+
+    // freeze key is not set on token
+    TokenHasNoFreezeKey = 172,
+
+    // amounts in transfer list are not net zero
+    TransfersNotZeroSumForToken = 173,
+
+    // a token symbol was not provided
+    MissingTokenSymbol = 174,
+
+    // the provided token symbol was too long
+    TokenSymbolTooLong = 175,
+
+    // KYC must be granted and account does not have KYC granted
+    AccountKycNotGrantedForToken = 176,
+
+    // KYC key is not set on token
+    TokenHasNoKycKey = 177,
+
+    // token balance is not sufficient for the transaction
+    InsufficientTokenBalance = 178,
+
+    // token transactions cannot be executed on deleted token
+    TokenWasDeleted = 179,
+
+    // supply key is not set on token
+    TokenHasNoSupplyKey = 180,
+
+    // wipe key is not set on token
+    TokenHasNoWipeKey = 181,
+
+    // the requested token mint amount would cause an invalid total supply
+    InvalidTokenMintAmount = 182,
+
+    // the requested token burn amount would cause an invalid total supply
+    InvalidTokenBurnAmount = 183,
+
+    // a required token-account relationship is missing
+    TokenNotAssociatedToAccount = 184,
+
+    // the target of a wipe operation was the token treasury account
+    CannotWipeTokenTreasuryAccount = 185,
+
+    // the provided KYC key was invalid
+    InvalidKycKey = 186,
+
+    // the provided wipe key was invalid
+    InvalidWipeKey = 187,
+
+    // the provided freeze key was invalid
+    InvalidFreezeKey = 188,
+
+    // the provided supply key was invalid
+    InvalidSupplyKey = 189,
+
+    // token Name is not provided
+    MissingTokenName = 190,
+
+    // token Name is too long
+    TokenNameTooLong = 191,
+
+    // the provided wipe amount must not be negative, zero or bigger than the token holder balance
+    InvalidWipingAmount = 192,
+
+    // token does not have Admin key set, thus update/delete transactions cannot be performed
+    TokenIsImmutable = 193,
+
+    // an associateToken operation specified a token already associated to the account
+    TokenAlreadyAssociatedToAccount = 194,
+
+    // an attempted operation is invalid until all token balances for the target account are zero
+    TransactionRequiresZeroTokenBalances = 195,
+
+    // an attempted operation is invalid because the account is a treasury
+    AccountIsTreasury = 196,
+
+    // same TokenIDs present in the token list
+    TokenIdRepeatedInTokenList = 197,
+
+    // exceeded the number of token transfers (both from and to) allowed for token transfer list
+    TokenTransferListSizeLimitExceeded = 198,
+
+    // tokenTransfersTransactionBody has no TokenTransferList
+    EmptyTokenTransferBody = 199,
+
+    // tokenTransfersTransactionBody has a TokenTransferList with no AccountAmounts
+    EmptyTokenTransferAccountAmounts = 200,
+
+    // the Scheduled entity does not exist; or has now expired, been deleted, or been executed
+    InvalidScheduleId = 201,
+
+    // the Scheduled entity cannot be modified. Admin key not set
+    ScheduleIsImmutable = 202,
+
+    // the provided Scheduled Payer does not exist
+    InvalidSchedulePayerId = 203,
+
+    // the Schedule Create Transaction TransactionID account does not exist
+    InvalidScheduleAccountId = 204,
+
+    // the provided sig map did not contain any new valid signatures from required signers of the scheduled transaction
+    NoNewValidSignatures = 205,
+
+    // the required signers for a scheduled transaction cannot be resolved, for example because they do not exist or have been deleted
+    UnresolvableRequiredSigners = 206,
+
+    // only whitelisted transaction types may be scheduled
+    ScheduledTransactionNotInWhitelist = 207,
+
+    // at least one of the signatures in the provided sig map did not represent a valid signature for any required signer
+    SomeSignaturesWereInvalid = 208,
+
+    // the scheduled field in the TransactionID may not be set to true
+    TransactionIdFieldNotAllowed = 209,
+
+    // a schedule already exists with the same identifying fields of an attempted ScheduleCreate
+    IdenticalScheduleAlreadyCreated = 210,
+
+    // a string field in the transaction has a UTF-8 encoding with the prohibited zero byte
+    InvalidZeroByteInString = 211,
+
+    // a schedule being signed or deleted has already been deleted
+    ScheduleAlreadyDeleted = 212,
+
+    // a schedule being signed or deleted has already been executed
+    ScheduleAlreadyExecuted = 213,
+
+    // consensusSubmitMessage request's message size is larger than allowed
+    MessageSizeTooLarge = 214,
+
+    // an operation was assigned to more than one throttle group in a given bucket
+    OperationRepeatedInBucketGroups = 215,
+
+    // the capacity needed to satisfy all opsPerSec groups in a bucket overflowed a signed 8-byte integral type
+    BucketCapacityOverflow = 216,
+
+    // given the network size in the address book, the node-level capacity for an operation would never be enough to accept a single request
+    NodeCapacityNotSufficientForOperation = 217,
+
+    // a bucket was defined without any throttle groups
+    BucketHasNoThrottleGroups = 218,
+
+    // a throttle group was granted zero opsPerSec
+    ThrottleGroupHasZeroOpsPerSec = 219,
+
+    // the throttle definitions file was updated, but some supported operations were not assigned a bucket
+    SuccessButMissingExpectedOperation = 220,
+
+    // the new contents for the throttle definitions system file were not valid protobuf
+    UnparseableThrottleDefinitions = 221,
+
+    // the new throttle definitions system file were invalid, and no more specific error could be divined
+    InvalidThrottleDefinitions = 222,
+
+    // the transaction references an account which has passed its expiration without renewal funds available, and currently remains in the ledger only because of the grace period given to expired entities
+    AccountExpiredAndPendingRemoval = 223,
+
+    // invalid token max supply
+    InvalidTokenMaxSupply = 224,
+
+    // invalid token nft serial number
+    InvalidTokenNftSerialNumber = 225,
+
+    // invalid nft id
+    InvalidNftId = 226,
+
+    // nft metadata is too long
+    MetadataTooLong = 227,
+
+    // repeated operations count exceeds the limit
+    BatchSizeLimitExceeded = 228,
+
+    // the range of data to be gathered is out of the set boundaries
+    InvalidQueryRange = 229,
+
+    // a custom fractional fee set a denominator of zero
+    FractionDividesByZero = 230,
+
+    // the transaction payer could not afford a custom fee
+    InsufficientPayerBalanceForCustomFee = 231,
+
+    // more than 10 custom fees were specified
+    CustomFeesListTooLong = 232,
+
+    // any of the feeCollector accounts for customFees is invalid
+    InvalidCustomFeeCollector = 233,
+
+    // any of the token Ids in customFees is invalid
+    InvalidTokenIdInCustomFees = 234,
+
+    // any of the token Ids in customFees are not associated to feeCollector
+    TokenNotAssociatedToFeeCollector = 235,
+
+    // a token cannot have more units minted due to its configured supply ceiling
+    TokenMaxSupplyReached = 236,
+
+    // the transaction attempted to move an NFT serial number from an account other than its owner
+    SenderDoesNotOwnNftSerialNo = 237,
+
+    // a custom fee schedule entry did not specify either a fixed or fractional fee
+    CustomFeeNotFullySpecified = 238,
+
+    // only positive fees may be assessed at this time
+    CustomFeeMustBePositive = 239,
+
+    // fee schedule key is not set on token
+    TokenHasNoFeeScheduleKey = 240,
+
+    // a fractional custom fee exceeded the range of a 64-bit signed integer
+    CustomFeeOutsideNumericRange = 241,
+
+    // a royalty cannot exceed the total fungible value exchanged for an NFT
+    RoyaltyFractionCannotExceedOne = 242,
+
+    // each fractional custom fee must have its maximum_amount, if specified, at least its minimum_amount
+    FractionalFeeMaxAmountLessThanMinAmount = 243,
+
+    // a fee schedule update tried to clear the custom fees from a token whose fee schedule was already empty
+    CustomScheduleAlreadyHasNoFees = 244,
+
+    // only tokens of type FUNGIBLE_COMMON can be used to as fee schedule denominations
+    CustomFeeDenominationMustBeFungibleCommon = 245,
+
+    // only tokens of type FUNGIBLE_COMMON can have fractional fees
+    CustomFractionalFeeOnlyAllowedForFungibleCommon = 246,
+
+    // the provided custom fee schedule key was invalid
+    InvalidCustomFeeScheduleKey = 247,
+
+    // the requested token mint metadata was invalid
+    InvalidTokenMintMetadata = 248,
+
+    // the requested token burn metadata was invalid
+    InvalidTokenBurnMetadata = 249,
+
+    // the treasury for a unique token cannot be changed until it owns no NFTs
+    CurrentTreasuryStillOwnsNfts = 250,
+
+    // an account cannot be dissociated from a unique token if it owns NFTs for the token
+    AccountStillOwnsNfts = 251,
+
+    // a NFT can only be burned when owned by the unique token's treasury
+    TreasuryMustOwnBurnedNft = 252,
+
+    // an account did not own the NFT to be wiped
+    AccountDoesNotOwnWipedNft = 253,
+
+    // an AccountAmount token transfers list referenced a token type other than FUNGIBLE_COMMON
+    AccountAmountTransfersOnlyAllowedForFungibleCommon = 254,
+
+    // all the NFTs allowed in the current price regime have already been minted
+    MaxNftsInPriceRegimeHaveBeenMinted = 255,
+
+    // the payer account has been marked as deleted
+    PayerAccountDeleted = 256,
+
+    // the reference chain of custom fees for a transferred token exceeded the maximum length of 2
+    CustomFeeChargingExceededMaxRecursionDepth = 257,
+
+    // more than 20 balance adjustments were to satisfy a CryptoTransfer and its implied custom fee payments
+    CustomFeeChargingExceededMaxAccountAmounts = 258,
+
+    // the sender account in the token transfer transaction could not afford a custom fee
+    InsufficientSenderAccountBalanceForCustomFee = 259,
+
+    // currently no more than 4,294,967,295 NFTs may be minted for a given unique token type
+    SerialNumberLimitReached = 260,
+
+    // only tokens of type NON_FUNGIBLE_UNIQUE can have royalty fees
+    CustomRoyaltyFeeOnlyAllowedForNonFungibleUnique = 261,
+
+    // the account has reached the limit on the automatic associations count
+    NoRemainingAutomaticAssociations = 262,
+
+    // already existing automatic associations are more than the new maximum automatic associations
+    ExistingAutomaticAssociationsExceedGivenLimit = 263,
+
+    // cannot set the number of automatic associations for an account more than the maximum allowed tokens.maxPerAccount
+    RequestedNumAutomaticAssociationsExceedsAssociationLimit = 264,
+
+    // token is paused. This Token cannot be a part of any kind of Transaction until unpaused
+    TokenIsPaused = 265,
+
+    // pause key is not set on token
+    TokenHasNoPauseKey = 266,
+
+    // the provided pause key was invalid
+    InvalidPauseKey = 267,
+
+    // the update file in a freeze transaction body must exist
+    FreezeUpdateFileDoesNotExist = 268,
+
+    // the hash of the update file in a freeze transaction body must match the in-memory hash
+    FreezeUpdateFileHashDoesNotMatch = 269,
+
+    // a FREEZE_UPGRADE transaction was handled with no previous update prepared
+    NoUpgradeHasBeenPrepared = 270,
+
+    // a FREEZE_ABORT transaction was handled with no scheduled freeze
+    NoFreezeIsScheduled = 271,
+
+    // the update file hash when handling a FREEZE_UPGRADE transaction differs from the file hash at the time of handling the PREPARE_UPGRADE transaction
+    UpdateFileHashChangedSincePrepareUpgrade = 272,
+
+    // the given freeze start time was in the (consensus) past
+    FreezeStartTimeMustBeFuture = 273,
+
+    // the prepared update file cannot be updated or appended until either the upgrade has been completed, or a FREEZE_ABORT has been handled
+    PreparedUpdateFileIsImmutable = 274,
+
+    // once a freeze is scheduled, it must be aborted before any other type of freeze can be performed
+    FreezeAlreadyScheduled = 275,
+
+    // if an NMT upgrade has been prepared, the following operation must be a FREEZE_UPGRADE
+    FreezeUpgradeInProgress = 276,
+
+    // if an NMT upgrade has been prepared, the subsequent FREEZE_UPGRADE transaction must confirm the id of the file to be used in the upgrade
+    UpdateFileIdDoesNotMatchPrepared = 277,
+
+    // if an NMT upgrade has been prepared, the subsequent FREEZE_UPGRADE transaction must confirm the hash of the file to be used in the upgrade
+    UpdateFileHashDoesNotMatchPrepared = 278,
+
+    // consensus throttle did not allow execution of this transaction. System is throttled at consensus level
+    ConsensusGasExhausted = 279,
+
+    // a precompiled contract succeeded, but was later reverted
+    RevertedSuccess = 280,
+
+    // all contract storage allocated to the current price regime has been consumed
+    MaxStorageInPriceRegimeHasBeenUsed = 281,
+
+    // an alias used in a CryptoTransfer transaction is not the serialization of a primitive Key message
+    InvalidAliasKey = 282,
+
+    // a fungible token transfer expected a different number of decimals than the involved type actually has
+    UnexpectedTokenDecimals = 283,
+
+    // the proxy account id is invalid or does not exist
+    InvalidProxyAccountId = 284,
+
+    // the transfer account id in CryptoDelete transaction is invalid or does not exist
+    InvalidTransferAccountId = 285,
+
+    // the fee collector account id in TokenFeeScheduleUpdate is invalid or does not exist
+    InvalidFeeCollectorAccountId = 286,
+
+    // the alias already set on an account cannot be updated using CryptoUpdate transaction
+    AliasIsImmutable = 287,
+
+    // an approved allowance specifies a spender account that is the same as the hbar/token owner account
+    SpenderAccountSameAsOwner = 288,
+
+    // the establishment or adjustment of an approved allowance cause the token allowance to exceed the token maximum supply
+    AmountExceedsTokenMaxSupply = 289,
+
+    // the specified amount for an approved allowance cannot be negative
+    NegativeAllowanceAmount = 290,
+
+    // the approveForAll flag cannot be set for a fungible token
+    CannotApproveForAllFungibleCommon = 291,
+
+    // the spender does not have an existing approved allowance with the hbar/token owner
+    SpenderDoesNotHaveAllowance = 292,
+
+    // the transfer amount exceeds the current approved allowance for the spender account
+    AmountExceedsAllowance = 293,
+
+    // the payer account of an approveAllowances or adjustAllowance transaction is attempting to go beyond the maximum allowed number of allowances
+    MaxAllowancesExceeded = 294,
+
+    // no allowances have been specified in the approval transaction
+    EmptyAllowances = 295,
+
+    // spender is repeated more than once in Crypto or Token or NFT allowance lists in a single CryptoApproveAllowance transaction
+    SpenderAccountRepeatedInAllowances = 296,
+
+    // serial numbers are repeated in nft allowance for a single spender account
+    RepeatedSerialNumsInNftAllowances = 297,
+
+    // fungible common token used in NFT allowances
+    FungibleTokenInNftAllowances = 298,
+
+    // non fungible token used in fungible token allowances
+    NftInFungibleTokenAllowances = 299,
+
+    // the account id specified as the owner is invalid or does not exist
+    InvalidAllowanceOwnerId = 300,
+
+    // the account id specified as the spender is invalid or does not exist
+    InvalidAllowanceSpenderId = 301,
+
+    // if the CryptoDeleteAllowance transaction has repeated crypto or token or Nft allowances to delete
+    RepeatedAllowancesToDelete = 302,
+
+    // if the account Id specified as the delegating spender is invalid or does not exist
+    InvalidDelegatingSpender = 303,
+
+    // the delegating Spender cannot grant approveForAll allowance on a NFT token type for another spender
+    DelegatingSpenderCannotGrantApproveForAll = 304,
+
+    // the delegating Spender cannot grant allowance on a NFT serial for another spender as it doesnt not have approveForAll granted on token-owner
+    DelegatingSpenderCannotGrantAllowance = 305,
+
+    // the scheduled transaction could not be created because it's expiration_time was too far in the future
+    ScheduleExpirationTimeTooFarInFuture = 306,
+
+    // the scheduled transaction could not be created because it's expiration_time was less than or equal to the consensus time
+    ScheduleExpirationTimeMustBeHigherThanConsensusTime = 307,
+
+    // the scheduled transaction could not be created because it would cause throttles to be violated on the specified expiration_time
+    ScheduleFutureThrottleExceeded = 308,
+
+    // the scheduled transaction could not be created because it would cause the gas limit to be violated on the specified expiration_time
+    ScheduleFutureGasLimitExceeded = 309,
+
+    // the ethereum transaction either failed parsing or failed signature validation, or some other EthereumTransaction error not covered by another response code
+    InvalidEthereumTransaction = 310,
+
+    // ethereumTransaction was signed against a chainId that this network does not support
+    WrongChainId = 311,
+
+    // this transaction specified an ethereumNonce that is not the current ethereumNonce of the account
+    WrongNonce = 312,
+
+    // the ethereum transaction specified an access list, which the network does not support
+    AccessListUnsupported = 313,
+
+    // a schedule being signed or deleted has passed it's expiration date and is pending execution if needed and then expiration
+    SchedulePendingExpiration = 314,
+
+    // a selfdestruct or ContractDelete targeted a contract that is a token treasury
+    ContractIsTokenTreasury = 315,
+
+    // a selfdestruct or ContractDelete targeted a contract with non-zero token balances
+    ContractHasNonZeroTokenBalances = 316,
+
+    // a contract referenced by a transaction is "detached"; that is, expired and lacking any hbar funds for auto-renewal payment
+    ContractExpiredAndPendingRemoval = 317,
+
+    // a ContractUpdate requested removal of a contract's auto-renew account, but that contract has no auto-renew account
+    ContractHasNoAutoRenewAccount = 318,
+
+    // a delete transaction submitted via HAPI set permanent_removal=true
+    PermanentRemovalRequiresSystemInitiation = 319,
+
+    // a CryptoCreate or ContractCreate used the deprecated proxyAccountID field
+    ProxyAccountIdFieldIsDeprecated = 320,
+
+    // an account set the staked_account_id to itself in CryptoUpdate or ContractUpdate transactions
+    SelfStakingIsNotAllowed = 321,
+
+    // the staking account id or staking node id given is invalid or does not exist
+    InvalidStakingId = 322,
+
+    // native staking, while implemented, has not yet enabled by the council
+    StakingNotEnabled = 323,
+
+    // the range provided in UtilPrng transaction is negative
+    InvalidPrngRange = 324,
+
+    // the maximum number of entities allowed in the current price regime have been created
+    MaxEntitiesInPriceRegimeHaveBeenCreated = 325,
+
+    // the full prefix signature for precompile is not valid
+    InvalidFullPrefixSignatureForPrecompile = 326,
+
+    // the combined balances of a contract and its auto-renew account (if any) did not cover the rent charged for net new storage used in a transaction
+    InsufficientBalancesForStorageRent = 327,
+
+    // a contract transaction tried to use more than the allowed number of child records, via either system contract records or internal contract creations
+    MaxChildRecordsExceeded = 328,
+
+    // the combined balances of a contract and its auto-renew account (if any) or balance of an account did not cover the auto-renewal fees in a transaction
+    InsufficientBalancesForRenewalFees = 329,
+
+    // a transaction's protobuf message includes unknown fields
+    TransactionHasUnknownFields = 330,
+
+    // the account cannot be modified. Account's key is not set
+    AccountIsImmutable = 331,
+
+    // an alias that is assigned to an account or contract cannot be assigned to another account or contract
+    AliasAlreadyAssigned = 332,
+
+    // a provided metadata key was invalid
+    InvalidMetadataKey = 333,
+
+    // metadata key is not set on token
+    TokenHasNoMetadataKey = 334,
+
+    // token Metadata is not provided
+    MissingTokenMetadata = 335,
+
+    // NFT serial numbers are missing in the TokenUpdateNftsTransactionBody
+    MissingSerialNumbers = 336,
+
+    // admin key is not set on token
+    TokenHasNoAdminKey = 337,
+
+    // a transaction failed because the consensus node identified is deleted from the address book
+    NodeDeleted = 338,
+
+    // a transaction failed because the consensus node identified is not valid or does not exist in state
+    InvalidNodeId = 339,
+
+    // a transaction failed because one or more entries in the list of service endpoints for the gossip_endpoint field is invalid
+    InvalidGossipEndpoint = 340,
+
+    // a transaction failed because the node account identifier provided does not exist or is not valid
+    InvalidNodeAccountId = 341,
+
+    // a transaction failed because the description field cannot be encoded as UTF-8 or is more than 100 bytes when encoded
+    InvalidNodeDescription = 342,
+
+    // a transaction failed because one or more entries in the list of service endpoints for the service_endpoint field is invalid
+    InvalidServiceEndpoint = 343,
+
+    // a transaction failed because the TLS certificate provided for the node is missing or invalid
+    InvalidGossipCaCertificate = 344,
+
+    // a transaction failed because the hash provided for the gRPC certificate is present but invalid
+    InvalidGrpcCertificate = 345,
+
+    // the maximum automatic associations value is not valid
+    InvalidMaxAutoAssociations = 346,
+
+    // the maximum number of nodes allowed in the address book have been created
+    MaxNodesCreated = 347,
+
+    // in ServiceEndpoint, domain_name and ipAddressV4 are mutually exclusive
+    IpFqdnCannotBeSetForSameEndpoint = 348,
+
+    // fully qualified domain name is not allowed in gossip_endpoint
+    GossipEndpointCannotHaveFqdn = 349,
+
+    // in ServiceEndpoint, domain_name size too large
+    FqdnSizeTooLarge = 350,
+
+    // serviceEndpoint is invalid
+    InvalidEndpoint = 351,
+
+    // the number of gossip endpoints exceeds the limit
+    GossipEndpointsExceededLimit = 352,
+
+    // the transaction attempted to use duplicate TokenReference
+    TokenReferenceRepeated = 353,
+
+    // the account id specified as the owner in TokenReject is invalid or does not exist
+    InvalidOwnerId = 354,
+
+    // the transaction attempted to use more than the allowed number of TokenReference
+    TokenReferenceListSizeLimitExceeded = 355,
+
+    // the number of service endpoints exceeds the limit
+    ServiceEndpointsExceededLimit = 356,
+
+    // the IPv4 address is invalid
+    InvalidIpv4Address = 357,
+
+    // the transaction attempted to use empty TokenReference list
+    EmptyTokenReferenceList = 358,
+
+    // the node account is not allowed to be updated
+    UpdateNodeAccountNotAllowed = 359,
+
+    // the token has no metadata or supply key
+    TokenHasNoMetadataOrSupplyKey = 360,
+
+    // the list of PendingAirdropIds is empty and MUST NOT be empty
+    EmptyPendingAirdropIdList = 361,
+
+    // a PendingAirdropId is repeated in a claim or cancel transaction
+    PendingAirdropIdRepeated = 362,
+
+    // the number of PendingAirdropId values in the list exceeds the maximum allowable number
+    PendingAirdropIdListTooLong = 363,
+
+    // a pending airdrop already exists for the specified NFT
+    PendingNftAirdropAlreadyExists = 364,
+
+    // the identified account is sender for one or more pending airdrop(s) and cannot be deleted
+    AccountHasPendingAirdrops = 365,
+
+    // consensus throttle did not allow execution of this transaction
+    ThrottledAtConsensus = 366,
+
+    // the provided pending airdrop id is invalid
+    InvalidPendingAirdropId = 367,
+
+    // the token to be airdropped has a fallback royalty fee and cannot be sent or claimed via an airdrop transaction
+    TokenAirdropWithFallbackRoyalty = 368,
+
+    // this airdrop claim is for a pending airdrop with an invalid token
+    InvalidTokenInPendingAirdrop = 369,
+
+    // a scheduled transaction configured to wait for expiry to execute was given an expiry time at which there is already too many transactions scheduled to expire
+    ScheduleExpiryIsBusy = 370,
+
+    // the provided gRPC certificate hash is invalid
+    InvalidGrpcCertificateHash = 371,
+
+    // a scheduled transaction configured to wait for expiry to execute was not given an explicit expiration time
+    MissingExpiryTime = 372,
+
+    // a contract operation attempted to schedule another transaction after it had already scheduled a recursive contract call
+    NoSchedulingAllowedAfterScheduledRecursion = 373,
+
+    // a contract can schedule recursive calls a finite number of times
+    RecursiveSchedulingLimitReached = 374,
+
+    // the network is waiting for the ledger ID to be set
+    WaitingForLedgerId = 375,
+
+    // the number of fee exempt keys exceeds the limit
+    MaxEntriesForFeeExemptKeyListExceeded = 376,
+
+    // the fee exempt key list contains duplicated keys
+    FeeExemptKeyListContainsDuplicatedKeys = 377,
+
+    // the fee exempt key list contains an invalid key
+    InvalidKeyInFeeExemptKeyList = 378,
+
+    // the provided fee schedule key contains an invalid key
+    InvalidFeeScheduleKey = 379,
+
+    // if a fee schedule key is not set when we create a topic we cannot add it on update
+    FeeScheduleKeyCannotBeUpdated = 380,
+
+    // if the topic's custom fees are updated the topic SHOULD have a fee schedule key
+    FeeScheduleKeyNotSet = 381,
+
+    // the fee amount is exceeding the amount that the payer is willing to pay
+    MaxCustomFeeLimitExceeded = 382,
+
+    // there are no corresponding custom fees
+    NoValidMaxCustomFee = 383,
+
+    // the provided list contains invalid max custom fee
+    InvalidMaxCustomFees = 384,
+
+    // the provided max custom fee list contains fees with duplicate denominations
+    DuplicateDenominationInMaxCustomFeeList = 385,
+
+    // the provided max custom fee list contains fees with duplicate account id
+    DuplicateAccountIdInMaxCustomFeeList = 386,
+
+    // max custom fees list is not supported for this operation
+    MaxCustomFeesIsNotSupported = 387,
+
+    // the list of batch transactions is empty
+    BatchListEmpty = 388,
+
+    // the list of batch transactions contains duplicated transactions
+    BatchListContainsDuplicates = 389,
+
+    // the list of batch transactions contains a transaction type that is in the AtomicBatch blacklist as configured in the network
+    BatchTransactionInBlacklist = 390,
+
+    // the inner transaction of a batch transaction failed
+    InnerTransactionFailed = 391,
+
+    // the inner transaction of a batch transaction is missing a batch key
+    MissingBatchKey = 392,
+
+    // the batch key is set for a non batch transaction
+    BatchKeySetOnNonInnerTransaction = 393,
+
+    // the batch key is not valid
+    InvalidBatchKey = 394,
 }
 
 impl From<proto::ResponseCode::ResponseCodeEnum> for Status {
@@ -381,7 +1125,7 @@ impl From<proto::ResponseCode::ResponseCodeEnum> for Status {
             CONTRACT_DELETED => Status::ContractDeleted,
             PLATFORM_NOT_ACTIVE => Status::PlatformNotActive,
             KEY_PREFIX_MISMATCH => Status::KeyPrefixMismatch,
-            PLATFORM_TRANSACTION_NOT_CREATED => Status::PlatformNotActive,
+            PLATFORM_TRANSACTION_NOT_CREATED => Status::TransactionNotCreated,
             INVALID_RENEWAL_PERIOD => Status::InvalidRenewalPeriod,
             INVALID_PAYER_ACCOUNT_ID => Status::InvalidPayerAccount,
             ACCOUNT_DELETED => Status::AccountDeleted,
@@ -417,7 +1161,319 @@ impl From<proto::ResponseCode::ResponseCodeEnum> for Status {
             FILE_UPLOADED_PROTO_INVALID => Status::FileUploadedProtoInvalid,
             FILE_UPLOADED_PROTO_NOT_SAVED_TO_DISK => Status::FileUploadedProtoNotSavedToDisk,
             FEE_SCHEDULE_FILE_PART_UPLOADED => Status::FeeScheduleFilePartUploaded,
-            EXCHANGE_RATE_CHANGE_LIMIT_EXCEEDED => Status::ExchangeRateChangeLimitExceeded
+            EXCHANGE_RATE_CHANGE_LIMIT_EXCEEDED => Status::ExchangeRateChangeLimitExceeded,
+            MAX_CONTRACT_STORAGE_EXCEEDED => Status::MaxContractStorageExceeded,
+            TRANSFER_ACCOUNT_SAME_AS_DELETE_ACCOUNT => Status::TransferAccountSameAsDeleteAccount,
+            TOTAL_LEDGER_BALANCE_INVALID => Status::TotalLedgerBalanceInvalid,
+            EXPIRATION_REDUCTION_NOT_ALLOWED => Status::ExpirationReductionNotAllowed,
+            MAX_GAS_LIMIT_EXCEEDED => Status::MaxGasLimitExceeded,
+            MAX_FILE_SIZE_EXCEEDED => Status::MaxFileSizeExceeded,
+            RECEIVER_SIG_REQUIRED => Status::ReceiverSigRequired,
+            INVALID_TOPIC_ID => Status::InvalidTopicId,
+            INVALID_ADMIN_KEY => Status::InvalidAdminKey,
+            INVALID_SUBMIT_KEY => Status::InvalidSubmitKey,
+            UNAUTHORIZED => Status::Unauthorized,
+            INVALID_TOPIC_MESSAGE => Status::InvalidTopicMessage,
+            INVALID_AUTORENEW_ACCOUNT => Status::InvalidAutorenewAccount,
+            AUTORENEW_ACCOUNT_NOT_ALLOWED => Status::AutorenewAccountNotAllowed,
+            TOPIC_EXPIRED => Status::TopicExpired,
+            INVALID_CHUNK_NUMBER => Status::InvalidChunkNumber,
+            INVALID_CHUNK_TRANSACTION_ID => Status::InvalidChunkTransactionId,
+            ACCOUNT_FROZEN_FOR_TOKEN => Status::AccountFrozenForToken,
+            TOKENS_PER_ACCOUNT_LIMIT_EXCEEDED => Status::TokensPerAccountLimitExceeded,
+            INVALID_TOKEN_ID => Status::InvalidTokenId,
+            INVALID_TOKEN_DECIMALS => Status::InvalidTokenDecimals,
+            INVALID_TOKEN_INITIAL_SUPPLY => Status::InvalidTokenInitialSupply,
+            INVALID_TREASURY_ACCOUNT_FOR_TOKEN => Status::InvalidTreasuryAccountForToken,
+            INVALID_TOKEN_SYMBOL => Status::InvalidTokenSymbol,
+            TOKEN_HAS_NO_FREEZE_KEY => Status::TokenHasNoFreezeKey,
+            TRANSFERS_NOT_ZERO_SUM_FOR_TOKEN => Status::TransfersNotZeroSumForToken,
+            MISSING_TOKEN_SYMBOL => Status::MissingTokenSymbol,
+            TOKEN_SYMBOL_TOO_LONG => Status::TokenSymbolTooLong,
+            ACCOUNT_KYC_NOT_GRANTED_FOR_TOKEN => Status::AccountKycNotGrantedForToken,
+            TOKEN_HAS_NO_KYC_KEY => Status::TokenHasNoKycKey,
+            INSUFFICIENT_TOKEN_BALANCE => Status::InsufficientTokenBalance,
+            TOKEN_WAS_DELETED => Status::TokenWasDeleted,
+            TOKEN_HAS_NO_SUPPLY_KEY => Status::TokenHasNoSupplyKey,
+            TOKEN_HAS_NO_WIPE_KEY => Status::TokenHasNoWipeKey,
+            INVALID_TOKEN_MINT_AMOUNT => Status::InvalidTokenMintAmount,
+            INVALID_TOKEN_BURN_AMOUNT => Status::InvalidTokenBurnAmount,
+            TOKEN_NOT_ASSOCIATED_TO_ACCOUNT => Status::TokenNotAssociatedToAccount,
+            CANNOT_WIPE_TOKEN_TREASURY_ACCOUNT => Status::CannotWipeTokenTreasuryAccount,
+            INVALID_KYC_KEY => Status::InvalidKycKey,
+            INVALID_WIPE_KEY => Status::InvalidWipeKey,
+            INVALID_FREEZE_KEY => Status::InvalidFreezeKey,
+            INVALID_SUPPLY_KEY => Status::InvalidSupplyKey,
+            MISSING_TOKEN_NAME => Status::MissingTokenName,
+            TOKEN_NAME_TOO_LONG => Status::TokenNameTooLong,
+            INVALID_WIPING_AMOUNT => Status::InvalidWipingAmount,
+            TOKEN_IS_IMMUTABLE => Status::TokenIsImmutable,
+            TOKEN_ALREADY_ASSOCIATED_TO_ACCOUNT => Status::TokenAlreadyAssociatedToAccount,
+            TRANSACTION_REQUIRES_ZERO_TOKEN_BALANCES => Status::TransactionRequiresZeroTokenBalances,
+            ACCOUNT_IS_TREASURY => Status::AccountIsTreasury,
+            TOKEN_ID_REPEATED_IN_TOKEN_LIST => Status::TokenIdRepeatedInTokenList,
+            TOKEN_TRANSFER_LIST_SIZE_LIMIT_EXCEEDED => Status::TokenTransferListSizeLimitExceeded,
+            EMPTY_TOKEN_TRANSFER_BODY => Status::EmptyTokenTransferBody,
+            EMPTY_TOKEN_TRANSFER_ACCOUNT_AMOUNTS => Status::EmptyTokenTransferAccountAmounts,
+            INVALID_SCHEDULE_ID => Status::InvalidScheduleId,
+            SCHEDULE_IS_IMMUTABLE => Status::ScheduleIsImmutable,
+            INVALID_SCHEDULE_PAYER_ID => Status::InvalidSchedulePayerId,
+            INVALID_SCHEDULE_ACCOUNT_ID => Status::InvalidScheduleAccountId,
+            NO_NEW_VALID_SIGNATURES => Status::NoNewValidSignatures,
+            UNRESOLVABLE_REQUIRED_SIGNERS => Status::UnresolvableRequiredSigners,
+            SCHEDULED_TRANSACTION_NOT_IN_WHITELIST => Status::ScheduledTransactionNotInWhitelist,
+            SOME_SIGNATURES_WERE_INVALID => Status::SomeSignaturesWereInvalid,
+            TRANSACTION_ID_FIELD_NOT_ALLOWED => Status::TransactionIdFieldNotAllowed,
+            IDENTICAL_SCHEDULE_ALREADY_CREATED => Status::IdenticalScheduleAlreadyCreated,
+            INVALID_ZERO_BYTE_IN_STRING => Status::InvalidZeroByteInString,
+            SCHEDULE_ALREADY_DELETED => Status::ScheduleAlreadyDeleted,
+            SCHEDULE_ALREADY_EXECUTED => Status::ScheduleAlreadyExecuted,
+            MESSAGE_SIZE_TOO_LARGE => Status::MessageSizeTooLarge,
+            OPERATION_REPEATED_IN_BUCKET_GROUPS => Status::OperationRepeatedInBucketGroups,
+            BUCKET_CAPACITY_OVERFLOW => Status::BucketCapacityOverflow,
+            NODE_CAPACITY_NOT_SUFFICIENT_FOR_OPERATION => Status::NodeCapacityNotSufficientForOperation,
+            BUCKET_HAS_NO_THROTTLE_GROUPS => Status::BucketHasNoThrottleGroups,
+            THROTTLE_GROUP_HAS_ZERO_OPS_PER_SEC => Status::ThrottleGroupHasZeroOpsPerSec,
+            SUCCESS_BUT_MISSING_EXPECTED_OPERATION => Status::SuccessButMissingExpectedOperation,
+            UNPARSEABLE_THROTTLE_DEFINITIONS => Status::UnparseableThrottleDefinitions,
+            INVALID_THROTTLE_DEFINITIONS => Status::InvalidThrottleDefinitions,
+            ACCOUNT_EXPIRED_AND_PENDING_REMOVAL => Status::AccountExpiredAndPendingRemoval,
+            INVALID_TOKEN_MAX_SUPPLY => Status::InvalidTokenMaxSupply,
+            INVALID_TOKEN_NFT_SERIAL_NUMBER => Status::InvalidTokenNftSerialNumber,
+            INVALID_NFT_ID => Status::InvalidNftId,
+            METADATA_TOO_LONG => Status::MetadataTooLong,
+            BATCH_SIZE_LIMIT_EXCEEDED => Status::BatchSizeLimitExceeded,
+            INVALID_QUERY_RANGE => Status::InvalidQueryRange,
+            FRACTION_DIVIDES_BY_ZERO => Status::FractionDividesByZero,
+            INSUFFICIENT_PAYER_BALANCE_FOR_CUSTOM_FEE => Status::InsufficientPayerBalanceForCustomFee,
+            CUSTOM_FEES_LIST_TOO_LONG => Status::CustomFeesListTooLong,
+            INVALID_CUSTOM_FEE_COLLECTOR => Status::InvalidCustomFeeCollector,
+            INVALID_TOKEN_ID_IN_CUSTOM_FEES => Status::InvalidTokenIdInCustomFees,
+            TOKEN_NOT_ASSOCIATED_TO_FEE_COLLECTOR => Status::TokenNotAssociatedToFeeCollector,
+            TOKEN_MAX_SUPPLY_REACHED => Status::TokenMaxSupplyReached,
+            SENDER_DOES_NOT_OWN_NFT_SERIAL_NO => Status::SenderDoesNotOwnNftSerialNo,
+            CUSTOM_FEE_NOT_FULLY_SPECIFIED => Status::CustomFeeNotFullySpecified,
+            CUSTOM_FEE_MUST_BE_POSITIVE => Status::CustomFeeMustBePositive,
+            TOKEN_HAS_NO_FEE_SCHEDULE_KEY => Status::TokenHasNoFeeScheduleKey,
+            CUSTOM_FEE_OUTSIDE_NUMERIC_RANGE => Status::CustomFeeOutsideNumericRange,
+            ROYALTY_FRACTION_CANNOT_EXCEED_ONE => Status::RoyaltyFractionCannotExceedOne,
+            FRACTIONAL_FEE_MAX_AMOUNT_LESS_THAN_MIN_AMOUNT => Status::FractionalFeeMaxAmountLessThanMinAmount,
+            CUSTOM_SCHEDULE_ALREADY_HAS_NO_FEES => Status::CustomScheduleAlreadyHasNoFees,
+            CUSTOM_FEE_DENOMINATION_MUST_BE_FUNGIBLE_COMMON => Status::CustomFeeDenominationMustBeFungibleCommon,
+            CUSTOM_FRACTIONAL_FEE_ONLY_ALLOWED_FOR_FUNGIBLE_COMMON => Status::CustomFractionalFeeOnlyAllowedForFungibleCommon,
+            INVALID_CUSTOM_FEE_SCHEDULE_KEY => Status::InvalidCustomFeeScheduleKey,
+            INVALID_TOKEN_MINT_METADATA => Status::InvalidTokenMintMetadata,
+            INVALID_TOKEN_BURN_METADATA => Status::InvalidTokenBurnMetadata,
+            CURRENT_TREASURY_STILL_OWNS_NFTS => Status::CurrentTreasuryStillOwnsNfts,
+            ACCOUNT_STILL_OWNS_NFTS => Status::AccountStillOwnsNfts,
+            TREASURY_MUST_OWN_BURNED_NFT => Status::TreasuryMustOwnBurnedNft,
+            ACCOUNT_DOES_NOT_OWN_WIPED_NFT => Status::AccountDoesNotOwnWipedNft,
+            ACCOUNT_AMOUNT_TRANSFERS_ONLY_ALLOWED_FOR_FUNGIBLE_COMMON => Status::AccountAmountTransfersOnlyAllowedForFungibleCommon,
+            MAX_NFTS_IN_PRICE_REGIME_HAVE_BEEN_MINTED => Status::MaxNftsInPriceRegimeHaveBeenMinted,
+            PAYER_ACCOUNT_DELETED => Status::PayerAccountDeleted,
+            CUSTOM_FEE_CHARGING_EXCEEDED_MAX_RECURSION_DEPTH => Status::CustomFeeChargingExceededMaxRecursionDepth,
+            CUSTOM_FEE_CHARGING_EXCEEDED_MAX_ACCOUNT_AMOUNTS => Status::CustomFeeChargingExceededMaxAccountAmounts,
+            INSUFFICIENT_SENDER_ACCOUNT_BALANCE_FOR_CUSTOM_FEE => Status::InsufficientSenderAccountBalanceForCustomFee,
+            SERIAL_NUMBER_LIMIT_REACHED => Status::SerialNumberLimitReached,
+            CUSTOM_ROYALTY_FEE_ONLY_ALLOWED_FOR_NON_FUNGIBLE_UNIQUE => Status::CustomRoyaltyFeeOnlyAllowedForNonFungibleUnique,
+            NO_REMAINING_AUTOMATIC_ASSOCIATIONS => Status::NoRemainingAutomaticAssociations,
+            EXISTING_AUTOMATIC_ASSOCIATIONS_EXCEED_GIVEN_LIMIT => Status::ExistingAutomaticAssociationsExceedGivenLimit,
+            REQUESTED_NUM_AUTOMATIC_ASSOCIATIONS_EXCEEDS_ASSOCIATION_LIMIT => Status::RequestedNumAutomaticAssociationsExceedsAssociationLimit,
+            TOKEN_IS_PAUSED => Status::TokenIsPaused,
+            TOKEN_HAS_NO_PAUSE_KEY => Status::TokenHasNoPauseKey,
+            INVALID_PAUSE_KEY => Status::InvalidPauseKey,
+            FREEZE_UPDATE_FILE_DOES_NOT_EXIST => Status::FreezeUpdateFileDoesNotExist,
+            FREEZE_UPDATE_FILE_HASH_DOES_NOT_MATCH => Status::FreezeUpdateFileHashDoesNotMatch,
+            NO_UPGRADE_HAS_BEEN_PREPARED => Status::NoUpgradeHasBeenPrepared,
+            NO_FREEZE_IS_SCHEDULED => Status::NoFreezeIsScheduled,
+            UPDATE_FILE_HASH_CHANGED_SINCE_PREPARE_UPGRADE => Status::UpdateFileHashChangedSincePrepareUpgrade,
+            FREEZE_START_TIME_MUST_BE_FUTURE => Status::FreezeStartTimeMustBeFuture,
+            PREPARED_UPDATE_FILE_IS_IMMUTABLE => Status::PreparedUpdateFileIsImmutable,
+            FREEZE_ALREADY_SCHEDULED => Status::FreezeAlreadyScheduled,
+            FREEZE_UPGRADE_IN_PROGRESS => Status::FreezeUpgradeInProgress,
+            UPDATE_FILE_ID_DOES_NOT_MATCH_PREPARED => Status::UpdateFileIdDoesNotMatchPrepared,
+            UPDATE_FILE_HASH_DOES_NOT_MATCH_PREPARED => Status::UpdateFileHashDoesNotMatchPrepared,
+            CONSENSUS_GAS_EXHAUSTED => Status::ConsensusGasExhausted,
+            REVERTED_SUCCESS => Status::RevertedSuccess,
+            MAX_STORAGE_IN_PRICE_REGIME_HAS_BEEN_USED => Status::MaxStorageInPriceRegimeHasBeenUsed,
+            INVALID_ALIAS_KEY => Status::InvalidAliasKey,
+            UNEXPECTED_TOKEN_DECIMALS => Status::UnexpectedTokenDecimals,
+            INVALID_PROXY_ACCOUNT_ID => Status::InvalidProxyAccountId,
+            INVALID_TRANSFER_ACCOUNT_ID => Status::InvalidTransferAccountId,
+            INVALID_FEE_COLLECTOR_ACCOUNT_ID => Status::InvalidFeeCollectorAccountId,
+            ALIAS_IS_IMMUTABLE => Status::AliasIsImmutable,
+            SPENDER_ACCOUNT_SAME_AS_OWNER => Status::SpenderAccountSameAsOwner,
+            AMOUNT_EXCEEDS_TOKEN_MAX_SUPPLY => Status::AmountExceedsTokenMaxSupply,
+            NEGATIVE_ALLOWANCE_AMOUNT => Status::NegativeAllowanceAmount,
+            CANNOT_APPROVE_FOR_ALL_FUNGIBLE_COMMON => Status::CannotApproveForAllFungibleCommon,
+            SPENDER_DOES_NOT_HAVE_ALLOWANCE => Status::SpenderDoesNotHaveAllowance,
+            AMOUNT_EXCEEDS_ALLOWANCE => Status::AmountExceedsAllowance,
+            MAX_ALLOWANCES_EXCEEDED => Status::MaxAllowancesExceeded,
+            EMPTY_ALLOWANCES => Status::EmptyAllowances,
+            SPENDER_ACCOUNT_REPEATED_IN_ALLOWANCES => Status::SpenderAccountRepeatedInAllowances,
+            REPEATED_SERIAL_NUMS_IN_NFT_ALLOWANCES => Status::RepeatedSerialNumsInNftAllowances,
+            FUNGIBLE_TOKEN_IN_NFT_ALLOWANCES => Status::FungibleTokenInNftAllowances,
+            NFT_IN_FUNGIBLE_TOKEN_ALLOWANCES => Status::NftInFungibleTokenAllowances,
+            INVALID_ALLOWANCE_OWNER_ID => Status::InvalidAllowanceOwnerId,
+            INVALID_ALLOWANCE_SPENDER_ID => Status::InvalidAllowanceSpenderId,
+            REPEATED_ALLOWANCES_TO_DELETE => Status::RepeatedAllowancesToDelete,
+            INVALID_DELEGATING_SPENDER => Status::InvalidDelegatingSpender,
+            DELEGATING_SPENDER_CANNOT_GRANT_APPROVE_FOR_ALL => Status::DelegatingSpenderCannotGrantApproveForAll,
+            DELEGATING_SPENDER_CANNOT_GRANT_ALLOWANCE => Status::DelegatingSpenderCannotGrantAllowance,
+            SCHEDULE_EXPIRATION_TIME_TOO_FAR_IN_FUTURE => Status::ScheduleExpirationTimeTooFarInFuture,
+            SCHEDULE_EXPIRATION_TIME_MUST_BE_HIGHER_THAN_CONSENSUS_TIME => Status::ScheduleExpirationTimeMustBeHigherThanConsensusTime,
+            SCHEDULE_FUTURE_THROTTLE_EXCEEDED => Status::ScheduleFutureThrottleExceeded,
+            SCHEDULE_FUTURE_GAS_LIMIT_EXCEEDED => Status::ScheduleFutureGasLimitExceeded,
+            INVALID_ETHEREUM_TRANSACTION => Status::InvalidEthereumTransaction,
+            WRONG_CHAIN_ID => Status::WrongChainId,
+            WRONG_NONCE => Status::WrongNonce,
+            ACCESS_LIST_UNSUPPORTED => Status::AccessListUnsupported,
+            SCHEDULE_PENDING_EXPIRATION => Status::SchedulePendingExpiration,
+            CONTRACT_IS_TOKEN_TREASURY => Status::ContractIsTokenTreasury,
+            CONTRACT_HAS_NON_ZERO_TOKEN_BALANCES => Status::ContractHasNonZeroTokenBalances,
+            CONTRACT_EXPIRED_AND_PENDING_REMOVAL => Status::ContractExpiredAndPendingRemoval,
+            CONTRACT_HAS_NO_AUTO_RENEW_ACCOUNT => Status::ContractHasNoAutoRenewAccount,
+            PERMANENT_REMOVAL_REQUIRES_SYSTEM_INITIATION => Status::PermanentRemovalRequiresSystemInitiation,
+            PROXY_ACCOUNT_ID_FIELD_IS_DEPRECATED => Status::ProxyAccountIdFieldIsDeprecated,
+            SELF_STAKING_IS_NOT_ALLOWED => Status::SelfStakingIsNotAllowed,
+            INVALID_STAKING_ID => Status::InvalidStakingId,
+            STAKING_NOT_ENABLED => Status::StakingNotEnabled,
+            INVALID_PRNG_RANGE => Status::InvalidPrngRange,
+            MAX_ENTITIES_IN_PRICE_REGIME_HAVE_BEEN_CREATED => Status::MaxEntitiesInPriceRegimeHaveBeenCreated,
+            INVALID_FULL_PREFIX_SIGNATURE_FOR_PRECOMPILE => Status::InvalidFullPrefixSignatureForPrecompile,
+            INSUFFICIENT_BALANCES_FOR_STORAGE_RENT => Status::InsufficientBalancesForStorageRent,
+            MAX_CHILD_RECORDS_EXCEEDED => Status::MaxChildRecordsExceeded,
+            INSUFFICIENT_BALANCES_FOR_RENEWAL_FEES => Status::InsufficientBalancesForRenewalFees,
+            TRANSACTION_HAS_UNKNOWN_FIELDS => Status::TransactionHasUnknownFields,
+            ACCOUNT_IS_IMMUTABLE => Status::AccountIsImmutable,
+            ALIAS_ALREADY_ASSIGNED => Status::AliasAlreadyAssigned,
+            INVALID_METADATA_KEY => Status::InvalidMetadataKey,
+            TOKEN_HAS_NO_METADATA_KEY => Status::TokenHasNoMetadataKey,
+            MISSING_TOKEN_METADATA => Status::MissingTokenMetadata,
+            MISSING_SERIAL_NUMBERS => Status::MissingSerialNumbers,
+            TOKEN_HAS_NO_ADMIN_KEY => Status::TokenHasNoAdminKey,
+            NODE_DELETED => Status::NodeDeleted,
+            INVALID_NODE_ID => Status::InvalidNodeId,
+            INVALID_GOSSIP_ENDPOINT => Status::InvalidGossipEndpoint,
+            INVALID_NODE_ACCOUNT_ID => Status::InvalidNodeAccountId,
+            INVALID_NODE_DESCRIPTION => Status::InvalidNodeDescription,
+            INVALID_SERVICE_ENDPOINT => Status::InvalidServiceEndpoint,
+            INVALID_GOSSIP_CA_CERTIFICATE => Status::InvalidGossipCaCertificate,
+            INVALID_GRPC_CERTIFICATE => Status::InvalidGrpcCertificate,
+            INVALID_MAX_AUTO_ASSOCIATIONS => Status::InvalidMaxAutoAssociations,
+            MAX_NODES_CREATED => Status::MaxNodesCreated,
+            IP_FQDN_CANNOT_BE_SET_FOR_SAME_ENDPOINT => Status::IpFqdnCannotBeSetForSameEndpoint,
+            GOSSIP_ENDPOINT_CANNOT_HAVE_FQDN => Status::GossipEndpointCannotHaveFqdn,
+            FQDN_SIZE_TOO_LARGE => Status::FqdnSizeTooLarge,
+            INVALID_ENDPOINT => Status::InvalidEndpoint,
+            GOSSIP_ENDPOINTS_EXCEEDED_LIMIT => Status::GossipEndpointsExceededLimit,
+            TOKEN_REFERENCE_REPEATED => Status::TokenReferenceRepeated,
+            INVALID_OWNER_ID => Status::InvalidOwnerId,
+            TOKEN_REFERENCE_LIST_SIZE_LIMIT_EXCEEDED => Status::TokenReferenceListSizeLimitExceeded,
+            SERVICE_ENDPOINTS_EXCEEDED_LIMIT => Status::ServiceEndpointsExceededLimit,
+            INVALID_IPV4_ADDRESS => Status::InvalidIpv4Address,
+            EMPTY_TOKEN_REFERENCE_LIST => Status::EmptyTokenReferenceList,
+            UPDATE_NODE_ACCOUNT_NOT_ALLOWED => Status::UpdateNodeAccountNotAllowed,
+            TOKEN_HAS_NO_METADATA_OR_SUPPLY_KEY => Status::TokenHasNoMetadataOrSupplyKey,
+            EMPTY_PENDING_AIRDROP_ID_LIST => Status::EmptyPendingAirdropIdList,
+            PENDING_AIRDROP_ID_REPEATED => Status::PendingAirdropIdRepeated,
+            PENDING_AIRDROP_ID_LIST_TOO_LONG => Status::PendingAirdropIdListTooLong,
+            PENDING_NFT_AIRDROP_ALREADY_EXISTS => Status::PendingNftAirdropAlreadyExists,
+            ACCOUNT_HAS_PENDING_AIRDROPS => Status::AccountHasPendingAirdrops,
+            THROTTLED_AT_CONSENSUS => Status::ThrottledAtConsensus,
+            INVALID_PENDING_AIRDROP_ID => Status::InvalidPendingAirdropId,
+            TOKEN_AIRDROP_WITH_FALLBACK_ROYALTY => Status::TokenAirdropWithFallbackRoyalty,
+            INVALID_TOKEN_IN_PENDING_AIRDROP => Status::InvalidTokenInPendingAirdrop,
+            SCHEDULE_EXPIRY_IS_BUSY => Status::ScheduleExpiryIsBusy,
+            INVALID_GRPC_CERTIFICATE_HASH => Status::InvalidGrpcCertificateHash,
+            MISSING_EXPIRY_TIME => Status::MissingExpiryTime,
+            NO_SCHEDULING_ALLOWED_AFTER_SCHEDULED_RECURSION => Status::NoSchedulingAllowedAfterScheduledRecursion,
+            RECURSIVE_SCHEDULING_LIMIT_REACHED => Status::RecursiveSchedulingLimitReached,
+            WAITING_FOR_LEDGER_ID => Status::WaitingForLedgerId,
+            MAX_ENTRIES_FOR_FEE_EXEMPT_KEY_LIST_EXCEEDED => Status::MaxEntriesForFeeExemptKeyListExceeded,
+            FEE_EXEMPT_KEY_LIST_CONTAINS_DUPLICATED_KEYS => Status::FeeExemptKeyListContainsDuplicatedKeys,
+            INVALID_KEY_IN_FEE_EXEMPT_KEY_LIST => Status::InvalidKeyInFeeExemptKeyList,
+            INVALID_FEE_SCHEDULE_KEY => Status::InvalidFeeScheduleKey,
+            FEE_SCHEDULE_KEY_CANNOT_BE_UPDATED => Status::FeeScheduleKeyCannotBeUpdated,
+            FEE_SCHEDULE_KEY_NOT_SET => Status::FeeScheduleKeyNotSet,
+            MAX_CUSTOM_FEE_LIMIT_EXCEEDED => Status::MaxCustomFeeLimitExceeded,
+            NO_VALID_MAX_CUSTOM_FEE => Status::NoValidMaxCustomFee,
+            INVALID_MAX_CUSTOM_FEES => Status::InvalidMaxCustomFees,
+            DUPLICATE_DENOMINATION_IN_MAX_CUSTOM_FEE_LIST => Status::DuplicateDenominationInMaxCustomFeeList,
+            DUPLICATE_ACCOUNT_ID_IN_MAX_CUSTOM_FEE_LIST => Status::DuplicateAccountIdInMaxCustomFeeList,
+            MAX_CUSTOM_FEES_IS_NOT_SUPPORTED => Status::MaxCustomFeesIsNotSupported,
+            BATCH_LIST_EMPTY => Status::BatchListEmpty,
+            BATCH_LIST_CONTAINS_DUPLICATES => Status::BatchListContainsDuplicates,
+            BATCH_TRANSACTION_IN_BLACKLIST => Status::BatchTransactionInBlacklist,
+            INNER_TRANSACTION_FAILED => Status::InnerTransactionFailed,
+            MISSING_BATCH_KEY => Status::MissingBatchKey,
+            BATCH_KEY_SET_ON_NON_INNER_TRANSACTION => Status::BatchKeySetOnNonInnerTransaction,
+            INVALID_BATCH_KEY => Status::InvalidBatchKey,
+        }
+    }
+}
+
+impl From<Status> for proto::ResponseCode::ResponseCodeEnum {
+    fn from(status: Status) -> Self {
+        // note: cannot fail; every status has a response code of the same value
+        ProtobufEnum::from_i32(status as i32).unwrap()
+    }
+}
+
+/// Format a `Status` as the name of its response code, e.g. `INSUFFICIENT_PAYER_BALANCE`.
+impl fmt::Display for Status {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        let code: proto::ResponseCode::ResponseCodeEnum = (*self).into();
+        f.write_str(code.descriptor().name())
+    }
+}
+
+// Parse the name of a code, as printed by `Display` and reported by the mirror node
+impl FromStr for Status {
+    type Err = Error;
+
+    fn from_str(s: &str) -> Result<Self, Self::Err> {
+        proto::ResponseCode::ResponseCodeEnum::values()
+            .iter()
+            .find(|code| code.descriptor().name() == s)
+            .map(|code| (*code).into())
+            .ok_or_else(|| ErrorKind::Parse("response code name").into())
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::Status;
+    use crate::proto::ResponseCode::ResponseCodeEnum;
+
+    #[test]
+    fn test_to_string() {
+        assert_eq!(Status::Ok.to_string(), "OK");
+        assert_eq!(Status::InsufficientPayerBalance.to_string(), "INSUFFICIENT_PAYER_BALANCE");
+        assert_eq!(
+            Status::MaxCustomFeesIsNotSupported.to_string(),
+            "MAX_CUSTOM_FEES_IS_NOT_SUPPORTED"
+        );
+
+        assert_eq!("INVALID_TOKEN_ID".parse::<Status>().ok(), Some(Status::InvalidTokenId));
+        assert!("NOT_A_CODE".parse::<Status>().is_err());
+    }
+
+    #[test]
+    fn test_from_proto() {
+        assert_eq!(Status::from(ResponseCodeEnum::INVALID_TOKEN_ID), Status::InvalidTokenId);
+        assert_eq!(
+            ResponseCodeEnum::from(Status::InvalidTokenId),
+            ResponseCodeEnum::INVALID_TOKEN_ID
+        );
+    }
+
+    #[test]
+    fn test_round_trip() {
+        use protobuf::ProtobufEnum;
+
+        for code in ResponseCodeEnum::values() {
+            assert_eq!(ResponseCodeEnum::from(Status::from(*code)), *code);
         }
     }
 }
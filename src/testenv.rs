@@ -0,0 +1,160 @@
+//! Funded accounts on a real network for integration tests.
+//!
+//! A [`TestEnv`] connects to a [local node](https://github.com/hashgraph/hedera-local-node)
+//! by default. To use another network, set:
+//!
+//! * `HEDERA_NODE_ADDRESS` – the `host:port` of the node, e.g. `0.testnet.hedera.com:50211`
+//! * `HEDERA_NODE` – the account of the node, e.g. `0:0:3`
+//! * `OPERATOR` and `OPERATOR_SECRET` – the account that funds the test accounts
+//!
+//! Only built with the `testenv` feature.
+//!
+//! [`TestEnv`]: struct.TestEnv.html
+
+use crate::{AccountId, Client, SecretKey};
+use failure::{err_msg, Error};
+use std::{env, mem, sync::Arc};
+
+// The defaults of a local node, whose genesis account funds everything
+const LOCAL_NODE_ADDRESS: &str = "127.0.0.1:50211";
+const LOCAL_NODE: &str = "0:0:3";
+const LOCAL_OPERATOR: &str = "0:0:2";
+const LOCAL_OPERATOR_SECRET: &str = "302e020100300506032b65700422042091132178e72057a1d7528025956fe39b0b847f200ab59b2fdd367017f3087137";
+
+/// The balance of `alice` and `bob`, in tinybars (10 hbars).
+pub const DEFAULT_BALANCE: u64 = 1_000_000_000;
+
+/// An account created for a test, and the secret key that signs for it.
+#[derive(Debug, Clone)]
+pub struct TestAccount {
+    pub id: AccountId,
+    pub secret: SecretKey,
+}
+
+/// A client for integration tests, with an operator and two funded accounts, `alice` and
+/// `bob`.
+///
+/// Every account created through the environment is deleted when it is closed or dropped,
+/// returning its remaining hbars to the operator.
+pub struct TestEnv {
+    pub client: Client,
+    pub operator: AccountId,
+    operator_secret: SecretKey,
+    // alice and bob are always the first two
+    accounts: Vec<TestAccount>,
+}
+
+impl TestEnv {
+    /// Connect to the network given by the environment, or the local node, and create
+    /// `alice` and `bob` with `DEFAULT_BALANCE` each.
+    pub fn new() -> Result<Self, Error> {
+        let address =
+            env::var("HEDERA_NODE_ADDRESS").unwrap_or_else(|_| LOCAL_NODE_ADDRESS.into());
+        let node = env::var("HEDERA_NODE").unwrap_or_else(|_| LOCAL_NODE.into());
+
+        let (operator, operator_secret) = match env::var("OPERATOR") {
+            Ok(operator) => (operator, env::var("OPERATOR_SECRET")?),
+            Err(_) => (LOCAL_OPERATOR.into(), LOCAL_OPERATOR_SECRET.into()),
+        };
+
+        let operator: AccountId = operator.parse()?;
+        let operator_secret: SecretKey = operator_secret.parse()?;
+
+        let mut client = Client::new(&address)?;
+        client.set_node(node.parse()?);
+
+        let secret = operator_secret.clone();
+        client.operator = Some(operator);
+        client.operator_secret = Some(Arc::new(move || Ok(secret.clone())));
+
+        let mut test_env = Self {
+            client,
+            operator,
+            operator_secret,
+            accounts: Vec::new(),
+        };
+
+        test_env.create_account(DEFAULT_BALANCE)?;
+        test_env.create_account(DEFAULT_BALANCE)?;
+
+        Ok(test_env)
+    }
+
+    /// The secret key of the operator.
+    #[inline]
+    pub fn operator_secret(&self) -> &SecretKey {
+        &self.operator_secret
+    }
+
+    #[inline]
+    pub fn alice(&self) -> &TestAccount {
+        &self.accounts[0]
+    }
+
+    #[inline]
+    pub fn bob(&self) -> &TestAccount {
+        &self.accounts[1]
+    }
+
+    /// Create an account with a new key and an initial balance of `balance` tinybars, paid
+    /// for by the operator. It is deleted along with the environment.
+    pub fn create_account(&mut self, balance: u64) -> Result<TestAccount, Error> {
+        let (secret, _) = SecretKey::generate("");
+
+        let receipt = self
+            .client
+            .create_account()
+            .key(secret.public())
+            .initial_balance(balance)
+            .execute()?
+            .get_receipt(&self.client)?;
+
+        let id = *receipt
+            .account_id
+            .ok_or_else(|| err_msg("receipt of account create has no account ID"))?;
+
+        let account = TestAccount { id, secret };
+        self.accounts.push(account.clone());
+
+        Ok(account)
+    }
+
+    /// Delete every account created through the environment, returning what is left of
+    /// their balances to the operator.
+    pub fn close(mut self) -> Result<(), Error> {
+        self.delete_accounts()
+    }
+
+    // Try to delete every account, even if some fail, and report the first failure
+    fn delete_accounts(&mut self) -> Result<(), Error> {
+        let mut result = Ok(());
+
+        for account in mem::replace(&mut self.accounts, Vec::new()) {
+            if let Err(error) = self.delete_account(&account) {
+                result = result.and(Err(error));
+            }
+        }
+
+        result
+    }
+
+    fn delete_account(&self, account: &TestAccount) -> Result<(), Error> {
+        let mut transaction = self.client.account(account.id).delete();
+        transaction.transfer_to(self.operator);
+
+        transaction
+            .sign(&account.secret)
+            .execute()?
+            .get_receipt(&self.client)?;
+
+        Ok(())
+    }
+}
+
+impl Drop for TestEnv {
+    fn drop(&mut self) {
+        if let Err(error) = self.delete_accounts() {
+            log::warn!(target: "hedera::testenv", "failed to delete test accounts: {}", error);
+        }
+    }
+}
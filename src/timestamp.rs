@@ -36,6 +36,12 @@ impl From<proto::Timestamp::Timestamp> for DateTime<Utc> {
     }
 }
 
+impl From<proto::Timestamp::TimestampSeconds> for DateTime<Utc> {
+    fn from(dt: proto::Timestamp::TimestampSeconds) -> Self {
+        Timestamp(dt.get_seconds(), 0).into()
+    }
+}
+
 impl ToProto<proto::Timestamp::Timestamp> for DateTime<Utc> {
     fn to_proto(&self) -> Result<proto::Timestamp::Timestamp, Error> {
         let mut timestamp = proto::Timestamp::Timestamp::new();
@@ -1,5 +1,4 @@
-//mod transaction_admin_delete;
-//mod transaction_admin_recover;
+mod transaction_batch;
 mod transaction_contract_call;
 mod transaction_contract_create;
 mod transaction_contract_delete;
@@ -10,53 +9,106 @@ mod transaction_crypto_delete;
 mod transaction_crypto_delete_claim;
 mod transaction_crypto_transfer;
 mod transaction_crypto_update;
+mod transaction_ethereum;
 mod transaction_file_append;
 mod transaction_file_create;
 mod transaction_file_delete;
 mod transaction_file_update;
+mod transaction_freeze;
+mod transaction_node_create;
+mod transaction_node_delete;
+mod transaction_node_update;
+mod transaction_prng;
+mod transaction_schedule_create;
+mod transaction_schedule_delete;
+mod transaction_schedule_sign;
+mod transaction_system_delete;
+mod transaction_system_undelete;
 
 pub use self::{
-    transaction_contract_call::*, transaction_contract_create::*, transaction_contract_update::*,
-    transaction_contract_delete::*, transaction_crypto_add_claim::*, transaction_crypto_create::*,
-    transaction_crypto_delete::*, transaction_crypto_delete_claim::*, transaction_crypto_transfer::*,
-    transaction_crypto_update::*, transaction_file_append::*, transaction_file_create::*,
-    transaction_file_delete::*, transaction_file_update::*,
+    transaction_batch::*, transaction_contract_call::*, transaction_contract_create::*,
+    transaction_contract_update::*, transaction_contract_delete::*, transaction_crypto_add_claim::*,
+    transaction_crypto_create::*, transaction_crypto_delete::*, transaction_crypto_delete_claim::*,
+    transaction_crypto_transfer::*, transaction_crypto_update::*, transaction_ethereum::*,
+    transaction_file_append::*, transaction_file_create::*, transaction_file_delete::*,
+    transaction_file_update::*, transaction_freeze::*, transaction_node_create::*,
+    transaction_node_delete::*, transaction_node_update::*, transaction_prng::*,
+    transaction_schedule_create::*, transaction_schedule_delete::*, transaction_schedule_sign::*,
+    transaction_system_delete::*, transaction_system_undelete::*,
 };
 
 use crate::{
-    crypto::SecretKey,
+    crypto::{Key, PublicKey, SecretKey, Signature},
     error::ErrorKind,
+    grpc_web::GrpcWebClient,
     proto::{
         self,
+        AddressBookService_grpc::{AddressBookService, AddressBookServiceClient},
         CryptoService_grpc::{CryptoService, CryptoServiceClient},
         FileService_grpc::{FileService, FileServiceClient},
+        FreezeService_grpc::{FreezeService, FreezeServiceClient},
+        ScheduleService_grpc::{ScheduleService, ScheduleServiceClient},
         SmartContractService_grpc::{SmartContractService, SmartContractServiceClient},
         ToProto,
+        UtilService_grpc::{UtilService, UtilServiceClient},
     },
-    AccountId, Client, TransactionId,
+    AccountId, Client, ContractId, ExchangeRate, FeeComponents, FeeData, FeeSchedule, FileId,
+    HederaFunctionality, Direction, Observer, RequestAttempt, RequestKind, Status, TransactionId,
+    TransactionResponse, WireMessage,
 };
 use futures::compat::Compat01As03;
-use failure::Error;
-use futures::{Future,};
+use failure::{format_err, Error};
+use futures::{
+    stream::{self, Stream, StreamExt},
+    Future,
+};
 use protobuf::Message;
 use query_interface::Object;
-use std::{any::Any, marker::PhantomData, mem::swap, sync::Arc, time::Duration};
+use sha2::{Digest, Sha384};
+use try_from::TryInto;
+use std::{
+    any::Any,
+    marker::PhantomData,
+    mem::swap,
+    sync::Arc,
+    time::{Duration, Instant},
+};
 
 use crate::proto::TransactionBody::TransactionBody_oneof_data::*;
 
+use self::transaction_schedule_create::ScheduledTransaction;
+
+pub(crate) use self::transaction_schedule_create::unschedulable;
+
+// The default maximum transaction fee
+const DEFAULT_FEE: u64 = 100_300_000;
+
+// How long a transaction is valid for after its valid start, by default
+const DEFAULT_VALID_DURATION: Duration = Duration::from_secs(120);
+
+// The size of a signature pair with an ed25519 public key prefix, when estimating fees
+const SIGNATURE_SIZE: usize = 100;
+
 pub struct TransactionBuilder<T> {
     id: Option<TransactionId>,
     node: Option<AccountId>,
     memo: Option<String>,
     generate_record: bool,
     fee: u64,
+    valid_duration: Duration,
+    batch_key: Option<PublicKey>,
     pub(crate) inner: Box<dyn Object>,
     phantom: PhantomData<T>,
 }
 
 pub struct TransactionRaw {
+    // the exact bytes of the body that every signature is over
     bytes: Vec<u8>,
+    // the body decoded from `bytes`, as `tx` may only hold the bytes
+    pub(crate) body: proto::TransactionBody::TransactionBody,
     pub(crate) tx: proto::Transaction::Transaction,
+    // the signatures in `tx`, with the key of each, as the list of signatures has no keys
+    signatures: Vec<(PublicKey, Signature)>,
 }
 
 enum TransactionKind<T> {
@@ -78,7 +130,16 @@ pub struct Transaction<T, S = TransactionBuilder<T>> {
     crypto_service: Arc<CryptoServiceClient>,
     file_service: Arc<FileServiceClient>,
     contract_service: Arc<SmartContractServiceClient>,
+    schedule_service: Arc<ScheduleServiceClient>,
+    freeze_service: Arc<FreezeServiceClient>,
+    address_book_service: Arc<AddressBookServiceClient>,
+    util_service: Arc<UtilServiceClient>,
     secret: Option<Arc<dyn Fn() -> Result<SecretKey, Error> + Send + Sync>>,
+    regenerate_id: bool,
+    accept_duplicate: bool,
+    observer: Option<Arc<dyn Observer>>,
+    retryable: Arc<dyn Fn(Status) -> bool + Send + Sync>,
+    web: Option<Arc<GrpcWebClient>>,
     kind: TransactionKind<T>,
     phantom: PhantomData<S>,
 }
@@ -92,13 +153,24 @@ impl<T: 'static> Transaction<T, TransactionBuilder<T>> {
             crypto_service: client.crypto.clone(),
             file_service: client.file.clone(),
             contract_service: client.contract.clone(),
+            schedule_service: client.schedule.clone(),
+            freeze_service: client.freeze.clone(),
+            address_book_service: client.address_book.clone(),
+            util_service: client.util.clone(),
             secret: client.operator_secret.clone(),
+            regenerate_id: client.regenerate_transaction_id,
+            accept_duplicate: false,
+            observer: client.observer.clone(),
+            retryable: client.retryable.clone(),
+            web: client.web.clone(),
             kind: TransactionKind::Builder(TransactionBuilder {
                 id: client.operator.map(TransactionId::new),
                 node: client.node,
                 memo: None,
                 inner: Box::<T>::new(inner) as Box<dyn Object>,
-                fee: 100_300_000,
+                fee: DEFAULT_FEE,
+                valid_duration: DEFAULT_VALID_DURATION,
+                batch_key: None,
                 generate_record: false,
                 phantom: PhantomData,
             }),
@@ -133,7 +205,31 @@ impl<T: 'static> Transaction<T, TransactionBuilder<T>> {
         self
     }
 
-    /// The fee the client pays, which is split between the network and the node.
+    /// Use a specific transaction ID, such as one generated ahead of time.
+    ///
+    /// The account of the ID pays for the transaction. Unlike `operator`, this keeps the
+    /// operator secret of the client, which still signs the transaction on execute.
+    pub fn transaction_id(&mut self, id: TransactionId) -> &mut Self {
+        if let Some(state) = self.as_builder() {
+            state.id = Some(id);
+        }
+
+        self
+    }
+
+    /// How long the transaction is valid for after the valid start of its ID;
+    /// it expires if it has not reached consensus by then. Defaults to 2 minutes.
+    pub fn valid_duration(&mut self, duration: Duration) -> &mut Self {
+        if let Some(state) = self.as_builder() {
+            state.valid_duration = duration;
+        }
+
+        self
+    }
+
+    /// The maximum fee the client pays, which is split between the network and the node.
+    ///
+    /// The transaction is charged its actual fee, up to this amount.
     pub fn fee(&mut self, fee: u64) -> &mut Self {
         if let Some(state) = self.as_builder() {
             state.fee = fee;
@@ -156,11 +252,187 @@ impl<T: 'static> Transaction<T, TransactionBuilder<T>> {
         self.build().sign(secret)
     }
 
-    pub fn execute_async(&mut self) -> impl Future<Output = Result<TransactionId, Error>> {
+    /// Sign with a key held elsewhere, such as in a hardware wallet. See
+    /// `Transaction::<T, TransactionRaw>::sign_with`.
+    pub fn sign_with(
+        &mut self,
+        public: &PublicKey,
+        signer: impl FnOnce(&[u8]) -> Result<Signature, Error>,
+    ) -> Result<&mut Transaction<T, TransactionRaw>, Error> {
+        self.build().sign_with(public, signer)
+    }
+
+    /// Sign with the operator of `client`. See
+    /// `Transaction::<T, TransactionRaw>::sign_with_operator`.
+    pub fn sign_with_operator(
+        &mut self,
+        client: &Client,
+    ) -> Result<&mut Transaction<T, TransactionRaw>, Error> {
+        self.build().sign_with_operator(client)
+    }
+
+    /// Freeze this transaction so it can no longer be edited.
+    ///
+    /// A frozen transaction can be signed, serialized with `to_bytes`, or executed.
+    pub fn freeze(&mut self) -> &mut Transaction<T, TransactionRaw> {
+        self.build()
+    }
+
+    /// Freeze this transaction, taking the operator and node from `client` if they
+    /// were not set.
+    pub fn freeze_with(&mut self, client: &Client) -> &mut Transaction<T, TransactionRaw> {
+        let mut operator_from_client = false;
+
+        if let Some(state) = self.as_builder() {
+            if state.id.is_none() {
+                state.id = client.operator.map(TransactionId::new);
+                operator_from_client = true;
+            }
+
+            if state.node.is_none() {
+                state.node = client.node;
+            }
+        }
+
+        if operator_from_client {
+            self.secret = client.operator_secret.clone();
+        }
+
+        self.build()
+    }
+
+    /// The key that must sign the batch this transaction is executed in, making this an
+    /// inner transaction of a `TransactionBatch`.
+    pub fn batch_key(&mut self, key: PublicKey) -> &mut Self {
+        if let Some(state) = self.as_builder() {
+            state.batch_key = Some(key);
+        }
+
+        self
+    }
+
+    /// Prepare this transaction to be added to a `TransactionBatch`: set its batch key and
+    /// node account `0.0.0`, then freeze it and sign it with the operator of `client`.
+    pub fn batchify(
+        &mut self,
+        client: &Client,
+        batch_key: PublicKey,
+    ) -> Result<&mut Transaction<T, TransactionRaw>, Error> {
+        if let Some(state) = self.as_builder() {
+            state.batch_key = Some(batch_key);
+
+            // inner transactions are submitted by the node of the batch
+            state.node = Some(AccountId::new(0, 0, 0));
+        }
+
+        self.freeze_with(client).sign_with_operator(client)
+    }
+
+    /// Schedule this transaction instead of executing it, by wrapping it in a schedule create
+    /// with the same transaction ID and node.
+    ///
+    /// The schedule create can then be executed to create a schedule which executes this
+    /// transaction once it has collected enough signatures.
+    pub fn schedule(&mut self) -> Transaction<TransactionScheduleCreate> {
+        let (id, node) = match self.as_builder() {
+            Some(state) => (state.id.clone(), state.node),
+            None => (None, None),
+        };
+
+        let scheduled = self.take_scheduled();
+
+        Transaction {
+            crypto_service: self.crypto_service.clone(),
+            file_service: self.file_service.clone(),
+            contract_service: self.contract_service.clone(),
+            schedule_service: self.schedule_service.clone(),
+            freeze_service: self.freeze_service.clone(),
+            address_book_service: self.address_book_service.clone(),
+            util_service: self.util_service.clone(),
+            secret: self.secret.clone(),
+            regenerate_id: self.regenerate_id,
+            accept_duplicate: self.accept_duplicate,
+            observer: self.observer.clone(),
+            retryable: self.retryable.clone(),
+            web: self.web.clone(),
+            kind: TransactionKind::Builder(TransactionBuilder {
+                id,
+                node,
+                memo: None,
+                inner: Box::new(TransactionScheduleCreate::scheduling(Some(scheduled)))
+                    as Box<dyn Object>,
+                fee: DEFAULT_FEE,
+                valid_duration: DEFAULT_VALID_DURATION,
+                batch_key: None,
+                generate_record: false,
+                phantom: PhantomData,
+            }),
+            phantom: PhantomData,
+        }
+    }
+
+    /// Estimate the fee of this transaction in tinybars from a fee schedule and an exchange
+    /// rate, for the given number of signatures.
+    ///
+    /// The estimate accounts for the size and signatures of the transaction. It does not
+    /// account for storage or gas, so contract calls and file operations can cost more.
+    pub fn estimate_fee(
+        &mut self,
+        schedule: &FeeSchedule,
+        rate: &ExchangeRate,
+        signatures: usize,
+    ) -> Result<u64, Error> {
+        let body: proto::TransactionBody::TransactionBody = match self.as_builder() {
+            Some(state) => state.to_proto()?,
+            None => Err(format_err!("cannot estimate the fee of an invalid transaction"))?,
+        };
+
+        let functionality = body
+            .data
+            .as_ref()
+            .map_or(HederaFunctionality::None, HederaFunctionality::of);
+
+        let fee_data = schedule
+            .fee_data(functionality)
+            .ok_or_else(|| format_err!("fee schedule has no price for {:?}", functionality))?;
+
+        let usage = FeeComponents {
+            constant: 1,
+            bytes_per_transaction: (body.compute_size() as usize + signatures * SIGNATURE_SIZE)
+                as i64,
+            verifications_per_transaction: signatures as i64,
+            ..FeeComponents::default()
+        };
+
+        let fee = fee_data.fee(&FeeData {
+            node: usage.clone(),
+            network: usage,
+            service: FeeComponents {
+                constant: 1,
+                ..FeeComponents::default()
+            },
+        });
+
+        Ok(rate.to_tinybars(fee)? as u64)
+    }
+
+    pub(crate) fn take_scheduled(&mut self) -> ScheduledTransaction {
+        match self.kind.take() {
+            TransactionKind::Builder(state) => ScheduledTransaction {
+                inner: state.inner,
+                fee: state.fee,
+                memo: state.memo,
+            },
+
+            _ => panic!("cannot schedule a transaction after it has been signed or executed"),
+        }
+    }
+
+    pub fn execute_async(&mut self) -> impl Future<Output = Result<TransactionResponse, Error>> {
         self.build().execute_async()
     }
 
-    pub fn execute(&mut self) -> Result<TransactionId, Error> {
+    pub fn execute(&mut self) -> Result<TransactionResponse, Error> {
         crate::RUNTIME
             .lock()
             .block_on(self.execute_async())
@@ -204,6 +476,56 @@ impl<T: 'static> Transaction<T, TransactionBuilder<T>> {
             _ => unreachable!(),
         }
     }
+
+    // Split this transaction into one transaction per chunk of a chunked transaction.
+    // Each chunk is offset from the valid start of the ID by its index in nanoseconds, so that
+    // every chunk has a unique ID.
+    pub(crate) fn take_chunks(&mut self, chunks: Vec<T>) -> Result<Vec<Transaction<T>>, Error>
+    where
+        T: Object + ToProto<proto::TransactionBody::TransactionBody_oneof_data> + 'static,
+    {
+        let state = match self.kind.take() {
+            TransactionKind::Builder(state) => state,
+            TransactionKind::Err(error) => Err(error)?,
+            _ => Err(format_err!("cannot split a transaction that was signed or executed"))?,
+        };
+
+        Ok(chunks
+            .into_iter()
+            .enumerate()
+            .map(|(index, inner)| (chrono::Duration::nanoseconds(index as i64), inner))
+            .map(|(offset, inner)| Transaction {
+                crypto_service: self.crypto_service.clone(),
+                file_service: self.file_service.clone(),
+                contract_service: self.contract_service.clone(),
+                schedule_service: self.schedule_service.clone(),
+                freeze_service: self.freeze_service.clone(),
+                address_book_service: self.address_book_service.clone(),
+                util_service: self.util_service.clone(),
+                secret: self.secret.clone(),
+                regenerate_id: self.regenerate_id,
+                accept_duplicate: self.accept_duplicate,
+                observer: self.observer.clone(),
+                retryable: self.retryable.clone(),
+                web: self.web.clone(),
+                kind: TransactionKind::Builder(TransactionBuilder {
+                    id: state.id.clone().map(|mut id| {
+                        id.transaction_valid_start = id.transaction_valid_start + offset;
+                        id
+                    }),
+                    node: state.node,
+                    memo: state.memo.clone(),
+                    inner: Box::<T>::new(inner) as Box<dyn Object>,
+                    fee: state.fee,
+                    valid_duration: state.valid_duration,
+                    batch_key: state.batch_key.clone(),
+                    generate_record: state.generate_record,
+                    phantom: PhantomData,
+                }),
+                phantom: PhantomData,
+            })
+            .collect())
+    }
 }
 
 impl<T: 'static> Transaction<T, TransactionRaw> {
@@ -228,92 +550,717 @@ impl<T: 'static> Transaction<T, TransactionRaw> {
 
     pub fn sign(&mut self, secret: &SecretKey) -> &mut Self {
         if let Some(state) = self.as_raw() {
-            // note: this cannot fail
-
-            let id = state
-                .tx
-                .get_body()
-                .transactionID
-                .as_ref()
-                .unwrap()
-                .clone();
-
-            // note: this cannot fail
-            let operator = id.accountID.as_ref().unwrap().clone();
-
-            // HACK: If an accountNum is < 1000 pretend it has a slightly more complex key structure
-            let signature = if operator.get_accountNum() < 1000 {
-                (&[&secret.sign(&state.bytes)][..]).to_proto().unwrap()
-            } else {
-                secret.sign(&state.bytes).to_proto().unwrap()
-            };
+            let signature = secret.sign(&state.bytes);
+            state.push_signature(secret.public(), signature);
+        }
+
+        self
+    }
+
+    /// Sign with a key held elsewhere, such as in a hardware wallet.
+    ///
+    /// `signer` is given the bytes to sign and must return their signature by the secret key
+    /// of `public`.
+    pub fn sign_with(
+        &mut self,
+        public: &PublicKey,
+        signer: impl FnOnce(&[u8]) -> Result<Signature, Error>,
+    ) -> Result<&mut Self, Error> {
+        let signature = match self.as_raw() {
+            Some(state) => signer(&state.bytes)?,
+            None => return Ok(self),
+        };
+
+        self.add_signature(public, &signature)
+    }
 
-            if !state.tx.has_sigs() {
-                state.tx.set_sigs(proto::BasicTypes::SignatureList::new());
+    /// Sign with the operator of `client` now, rather than when the transaction is executed.
+    pub fn sign_with_operator(&mut self, client: &Client) -> Result<&mut Self, Error> {
+        let secret = client
+            .operator_secret
+            .as_ref()
+            .ok_or_else(|| ErrorKind::MissingField("operator_secret"))?;
+
+        let key = secret()?;
+
+        // the same operator should not sign again on execute
+        if self.secret.as_ref().map_or(false, |own| Arc::ptr_eq(own, secret)) {
+            self.secret = None;
+        }
+
+        // the operator signature goes first, whenever it is made
+        if let Some(state) = self.as_raw() {
+            state.sign_as_operator(&key);
+        }
+
+        Ok(self)
+    }
+
+    /// Add a signature collected from another party, made by the secret key of `public`
+    /// over the bytes returned by `body_bytes`.
+    ///
+    /// Fails if the signature is not valid for this transaction.
+    pub fn add_signature(
+        &mut self,
+        public: &PublicKey,
+        signature: &Signature,
+    ) -> Result<&mut Self, Error> {
+        if let Some(state) = self.as_raw() {
+            if !public.verify(&state.bytes, signature)? {
+                Err(format_err!("signature is not valid for this transaction: {}", public))?;
             }
 
-            // note: this cannot fail
-            let signatures = &mut state.tx.sigs.as_mut().unwrap().sigs;
-            signatures.push(signature);
+            state.push_signature(public.clone(), signature.clone());
         }
 
-        self
+        Ok(self)
+    }
+
+    /// The bytes of the transaction body, which each party must sign.
+    pub fn body_bytes(&self) -> Result<Vec<u8>, Error> {
+        Ok(self.raw()?.bytes.clone())
+    }
+
+    /// The signatures collected so far, with the key that made each, in the order they were
+    /// added.
+    ///
+    /// The operator signature is only added when the transaction is executed. A transaction
+    /// read by `from_bytes` only has the keys of signatures given in its signature map, as
+    /// written by `to_bytes`; a bare list of signatures is still sent, but not returned here.
+    pub fn signatures(&self) -> Result<Vec<(PublicKey, Signature)>, Error> {
+        Ok(self.raw()?.signatures.clone())
     }
 
-    pub fn execute(&mut self) -> Result<TransactionId, Error> {
+    /// The kind of transaction this is, such as after reading it with `from_bytes`.
+    ///
+    /// The getters below read the fields of a kind; this crate has no token transactions, so
+    /// there are none for tokens.
+    pub fn functionality(&self) -> Result<HederaFunctionality, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(data) => HederaFunctionality::of(data),
+            None => HederaFunctionality::None,
+        })
+    }
+
+    /// The ID of this transaction, whose account pays for it.
+    pub fn transaction_id(&self) -> Result<TransactionId, Error> {
+        Ok(self.raw()?.body.get_transactionID().clone().into())
+    }
+
+    /// The node this transaction is to be submitted to.
+    pub fn node_id(&self) -> Result<AccountId, Error> {
+        Ok(self.raw()?.body.get_nodeAccountID().clone().into())
+    }
+
+    /// The maximum fee the payer is willing to pay, in tinybars.
+    pub fn fee(&self) -> Result<u64, Error> {
+        Ok(self.raw()?.body.get_transactionFee())
+    }
+
+    /// The memo of this transaction, or an empty string if it has none.
+    pub fn memo(&self) -> Result<String, Error> {
+        Ok(self.raw()?.body.get_memo().to_owned())
+    }
+
+    /// How long this transaction is valid for after the valid start of its ID.
+    pub fn valid_duration(&self) -> Result<Duration, Error> {
+        self.raw()?.body.get_transactionValidDuration().clone().try_into()
+    }
+
+    /// The hbar transfers of a crypto transfer, or `None` for any other kind of transaction.
+    pub fn transfers(&self) -> Result<Option<Vec<(AccountId, i64)>>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(cryptoTransfer(data)) => Some(data.get_transfers().clone().into()),
+            _ => None,
+        })
+    }
+
+    /// The account an account update or delete is of, or `None` for any other kind of
+    /// transaction.
+    pub fn account_id(&self) -> Result<Option<AccountId>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(cryptoUpdateAccount(data)) => Some(data.get_accountIDToUpdate().clone().into()),
+            Some(cryptoDelete(data)) => Some(data.get_deleteAccountID().clone().into()),
+            _ => None,
+        })
+    }
+
+    /// The key of the account an account create makes, or `None` for any other kind of
+    /// transaction.
+    pub fn account_key(&self) -> Result<Option<Key>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(cryptoCreateAccount(data)) => Some(data.get_key().clone().try_into()?),
+            _ => None,
+        })
+    }
+
+    /// The tinybars an account create puts into the new account, or `None` for any other kind
+    /// of transaction.
+    pub fn initial_balance(&self) -> Result<Option<u64>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(cryptoCreateAccount(data)) => Some(data.get_initialBalance()),
+            _ => None,
+        })
+    }
+
+    /// The file a file append, update, or delete is of, or `None` for any other kind of
+    /// transaction.
+    pub fn file_id(&self) -> Result<Option<FileId>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(fileAppend(data)) => Some(data.get_fileID().clone().into()),
+            Some(fileUpdate(data)) => Some(data.get_fileID().clone().into()),
+            Some(fileDelete(data)) => Some(data.get_fileID().clone().into()),
+            _ => None,
+        })
+    }
+
+    /// The contents a file create, append, or update writes, or `None` for any other kind of
+    /// transaction.
+    pub fn file_contents(&self) -> Result<Option<Vec<u8>>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(fileCreate(data)) => Some(data.get_contents().to_vec()),
+            Some(fileAppend(data)) => Some(data.get_contents().to_vec()),
+            Some(fileUpdate(data)) => Some(data.get_contents().to_vec()),
+            _ => None,
+        })
+    }
+
+    /// The contract a contract call, update, or delete is of, or `None` for any other kind of
+    /// transaction.
+    pub fn contract_id(&self) -> Result<Option<ContractId>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(contractCall(data)) => Some(data.get_contractID().clone().into()),
+            Some(contractUpdateInstance(data)) => Some(data.get_contractID().clone().into()),
+            Some(contractDeleteInstance(data)) => Some(data.get_contractID().clone().into()),
+            _ => None,
+        })
+    }
+
+    /// The encoded function and parameters of a contract call, or `None` for any other kind of
+    /// transaction.
+    pub fn function_parameters(&self) -> Result<Option<Vec<u8>>, Error> {
+        Ok(match &self.raw()?.body.data {
+            Some(contractCall(data)) => Some(data.get_functionParameters().to_vec()),
+            _ => None,
+        })
+    }
+
+    /// Serialize this transaction with the signatures it has so far, so it can be moved to
+    /// another machine to be signed or executed.
+    ///
+    /// The operator signature is only added when the transaction is executed.
+    pub fn to_bytes(&self) -> Result<Vec<u8>, Error> {
+        let state = self.raw()?;
+        let mut tx = state.tx.clone();
+
+        // a signature map names the key of each signature, for `from_bytes` to read back
+        tx.set_sigMap(state.signature_map());
+
+        Ok(tx.write_to_bytes()?)
+    }
+
+    #[inline]
+    fn raw(&self) -> Result<&TransactionRaw, Error> {
+        match &self.kind {
+            TransactionKind::Raw(state) => Ok(state),
+            TransactionKind::Err(error) => Err(format_err!("{}", error)),
+
+            // not possible in safe rust
+            TransactionKind::Builder(_) => unreachable!(),
+
+            TransactionKind::Empty => Err(format_err!("transaction already executed")),
+        }
+    }
+
+    pub fn execute(&mut self) -> Result<TransactionResponse, Error> {
         crate::RUNTIME
             .lock()
             .block_on(self.execute_async())
     }
 
-    pub fn execute_async(&mut self) -> impl Future<Output = Result<TransactionId, Error>> {
+    pub fn execute_async(&mut self) -> impl Future<Output = Result<TransactionResponse, Error>> {
         let crypto = self.crypto_service.clone();
         let file = self.file_service.clone();
         let contract = self.contract_service.clone();
+        let schedule = self.schedule_service.clone();
+        // named to not shadow the freeze variant of the transaction body
+        let freeze_service = self.freeze_service.clone();
+        let address_book = self.address_book_service.clone();
+        let util = self.util_service.clone();
+
+        // the ID can only be regenerated if the operator is the only signer, as any other
+        // signatures would be of the old ID
+        let secret = match self.as_raw() {
+            Some(state) if self.regenerate_id && state.tx.get_sigs().sigs.is_empty() => {
+                self.secret.clone()
+            }
+
+            _ => None,
+        };
+
+        let accept_duplicate = self.accept_duplicate;
+        let observer = self.observer.clone();
+        let retryable = self.retryable.clone();
+        let web = self.web.clone();
         let state = self.take_raw();
 
         async move {
-            let state = state?;
-
-            let mut tx = state.tx;
-            let id = tx
-                .get_body()
-                .transactionID
-                .as_ref()
-                .unwrap()
-                .clone();
-
-            log::trace!(target: "hedera::transaction", "sent: {:#?}", tx);
-
-            let o = grpc::RequestOptions::default();
-            let response = match tx.mut_body().data {
-                //////////////////////// CRYPTO TRANSACTIONS
-                Some(cryptoCreateAccount(_)) => crypto.create_account(o, tx),
-                Some(cryptoUpdateAccount(_)) => crypto.update_account(o, tx),
-                Some(cryptoTransfer(_)) => crypto.crypto_transfer(o, tx),
-                Some(cryptoDeleteClaim(_)) => crypto.delete_claim(o, tx),
-                Some(cryptoDelete(_)) => crypto.crypto_delete(o, tx),
-                //////////////////////// FILE TRANSACTIONS
-                Some(fileCreate(_)) => file.create_file(o, tx),
-                Some(fileAppend(_)) => file.append_content(o, tx),
-                //////////////////////// CONTRACT TRANSACTIONS
-                Some(contractCreateInstance(_)) => contract.create_contract(o, tx),
-                Some(contractUpdateInstance(_)) => contract.update_contract(o, tx),
-                Some(contractDeleteInstance(_)) => contract.delete_contract(o, tx),
-                Some(contractCall(_)) => contract.contract_call_method(o, tx),
-
-                _ => unimplemented!(),
-            };
+            let mut state = state?;
+            let mut id: TransactionId = state.body.get_transactionID().clone().into();
+            let mut attempt = 0;
+
+            loop {
+                let tx = state.tx.clone();
+                let node_id = state.body.get_nodeAccountID().clone().into();
+                let transaction_hash = Sha384::digest(&tx.write_to_bytes()?).to_vec();
+
+                log::debug!(target: "hedera::transaction", "submitting {} to {}", id, node_id);
+                log::trace!(target: "hedera::transaction", "sent: {:#?}", tx);
+
+                if let Some(observer) = &observer {
+                    observer.on_message(&WireMessage::new(
+                        RequestKind::Transaction,
+                        Direction::Sent,
+                        &tx,
+                    ));
+                }
+
+                let start = Instant::now();
+                let report = |status: Option<Status>| {
+                    if let Some(observer) = &observer {
+                        observer.on_attempt(&RequestAttempt {
+                            kind: RequestKind::Transaction,
+                            node: Some(node_id),
+                            transaction_id: Some(id.clone()),
+                            attempt: attempt + 1,
+                            status,
+                            latency: start.elapsed(),
+                        });
+                    }
+                };
+
+                let response = if let Some(web) = &web {
+                    web.unary(transaction_method(&state.body), &tx).await
+                } else {
+                    let o = grpc::RequestOptions::default();
+                    let response = match state.body.data {
+                        //////////////////////// CRYPTO TRANSACTIONS
+                        Some(cryptoCreateAccount(_)) => crypto.create_account(o, tx),
+                        Some(cryptoUpdateAccount(_)) => crypto.update_account(o, tx),
+                        Some(cryptoTransfer(_)) => crypto.crypto_transfer(o, tx),
+                        Some(cryptoDeleteClaim(_)) => crypto.delete_claim(o, tx),
+                        Some(cryptoDelete(_)) => crypto.crypto_delete(o, tx),
+                        //////////////////////// FILE TRANSACTIONS
+                        Some(fileCreate(_)) => file.create_file(o, tx),
+                        Some(fileAppend(_)) => file.append_content(o, tx),
+                        Some(fileUpdate(_)) => file.update_file(o, tx),
+                        Some(fileDelete(_)) => file.delete_file(o, tx),
+                        //////////////////////// SYSTEM TRANSACTIONS
+                        Some(systemDelete(ref data)) if data.has_contractID() => {
+                            contract.system_delete(o, tx)
+                        }
+                        Some(systemDelete(_)) => file.system_delete(o, tx),
+                        Some(systemUndelete(ref data)) if data.has_contractID() => {
+                            contract.system_undelete(o, tx)
+                        }
+                        Some(systemUndelete(_)) => file.system_undelete(o, tx),
+                        //////////////////////// CONTRACT TRANSACTIONS
+                        Some(contractCreateInstance(_)) => contract.create_contract(o, tx),
+                        Some(contractUpdateInstance(_)) => contract.update_contract(o, tx),
+                        Some(contractDeleteInstance(_)) => contract.delete_contract(o, tx),
+                        Some(contractCall(_)) => contract.contract_call_method(o, tx),
+                        Some(ethereumTransaction(_)) => contract.call_ethereum(o, tx),
+                        //////////////////////// FREEZE TRANSACTIONS
+                        Some(freeze(_)) => freeze_service.freeze(o, tx),
+                        //////////////////////// NODE TRANSACTIONS
+                        Some(nodeCreate(_)) => address_book.create_node(o, tx),
+                        Some(nodeUpdate(_)) => address_book.update_node(o, tx),
+                        Some(nodeDelete(_)) => address_book.delete_node(o, tx),
+                        //////////////////////// UTIL TRANSACTIONS
+                        Some(util_prng(_)) => util.prng(o, tx),
+                        Some(atomic_batch(_)) => util.atomic_batch(o, tx),
+                        //////////////////////// SCHEDULE TRANSACTIONS
+                        Some(scheduleCreate(_)) => schedule.create_schedule(o, tx),
+                        Some(scheduleDelete(_)) => schedule.delete_schedule(o, tx),
+                        Some(scheduleSign(_)) => schedule.sign_schedule(o, tx),
+
+                        _ => unimplemented!(),
+                    };
+
+                    Compat01As03::new(response.drop_metadata()).await.map_err(Error::from)
+                };
 
-            let response = Compat01As03::new(response.drop_metadata()).await?;
-            log::trace!("recv: {:#?}", response);
+                let response = response.map_err(|error| {
+                    report(None);
+                    error
+                })?;
 
-            try_precheck!(response).map(|_| id.into())
+                log::trace!(target: "hedera::transaction", "recv: {:#?}", response);
+
+                if let Some(observer) = &observer {
+                    observer.on_message(&WireMessage::new(
+                        RequestKind::Transaction,
+                        Direction::Received,
+                        &response,
+                    ));
+                }
+
+                let code: Status = response.get_nodeTransactionPrecheckCode().into();
+                report(Some(code));
+
+                match (code, &secret) {
+                    (Status::TransactionExpired, Some(secret)) if attempt < 5 => {
+                        attempt += 1;
+                        id = state.regenerate_id(&secret()?)?;
+
+                        log::debug!(target: "hedera::transaction", "expired; retrying as {}", id);
+                    }
+
+                    (Status::DuplicateTransaction, _) if accept_duplicate => {
+                        log::debug!(target: "hedera::transaction", "{} already submitted", id);
+
+                        break Ok(TransactionResponse {
+                            transaction_id: id,
+                            node_id,
+                            transaction_hash,
+                        });
+                    }
+
+                    (Status::Ok, _) => {
+                        break Ok(TransactionResponse {
+                            transaction_id: id,
+                            node_id,
+                            transaction_hash,
+                        });
+                    }
+
+                    (status, _) if attempt < 5 && retryable(status) => {
+                        attempt += 1;
+                        log::debug!(
+                            target: "hedera::transaction",
+                            "{:?}; retry {} of 5",
+                            status,
+                            attempt
+                        );
+
+                        let delay = Duration::from_secs((attempt * 2) as u64);
+                        tokio::timer::delay(Instant::now() + delay).await;
+                    }
+
+                    (status, _) => {
+                        log::debug!(target: "hedera::transaction", "{} failed: {:?}", id, status);
+
+                        Err(ErrorKind::PreCheck {
+                            status,
+                            transaction_id: id,
+                        })?
+                    }
+                }
+            }
         }
     }
 }
 
+impl TransactionRaw {
+    // Sign as the operator of the transaction, ahead of any other signatures
+    fn sign_as_operator(&mut self, secret: &SecretKey) {
+        // note: cannot fail
+        let operator = self.body.get_transactionID().get_accountID().clone();
+
+        let signed = secret.sign(&self.bytes);
+
+        // HACK: If an accountNum is < 1000 pretend it has a slightly more complex key structure
+        let signature = if operator.get_accountNum() < 1000 {
+            (&[&signed][..]).to_proto().unwrap()
+        } else {
+            signed.to_proto().unwrap()
+        };
+
+        if !self.tx.has_sigs() {
+            self.tx.set_sigs(proto::BasicTypes::SignatureList::new());
+        }
+
+        match &self.body.data {
+            Some(cryptoTransfer(data)) => {
+                // Insert a signature for the operator if the operator
+                // is sending any monies
+                for transfer in &data.transfers.as_ref().unwrap().accountAmounts {
+                    if transfer.accountID.as_ref().unwrap() == &operator {
+                        self.tx.sigs.as_mut().unwrap().sigs.push(signature.clone());
+                    }
+                }
+            }
+
+            _ => {}
+        }
+
+        self.tx.sigs.as_mut().unwrap().sigs.insert(0, signature);
+        self.signatures.insert(0, (secret.public(), signed));
+    }
+
+    // Replace the transaction ID with a new one for the same account, and sign again as the
+    // operator. Only valid if the operator is the only signer.
+    fn regenerate_id(&mut self, secret: &SecretKey) -> Result<TransactionId, Error> {
+        let account = self.body.get_transactionID().get_accountID().clone();
+        let id = TransactionId::new(account.into());
+
+        self.body.set_transactionID(id.to_proto()?);
+        self.bytes = self.body.write_to_bytes()?;
+        self.set_body_data();
+        self.tx.clear_sigs();
+        self.signatures.clear();
+        self.sign_as_operator(secret);
+
+        Ok(id)
+    }
+
+    fn push_signature(&mut self, public: PublicKey, signature: Signature) {
+        // note: this cannot fail
+        let operator = self.body.get_transactionID().get_accountID();
+
+        // HACK: If an accountNum is < 1000 pretend it has a slightly more complex key structure
+        let signed = if operator.get_accountNum() < 1000 {
+            (&[&signature][..]).to_proto().unwrap()
+        } else {
+            signature.to_proto().unwrap()
+        };
+
+        if !self.tx.has_sigs() {
+            self.tx.set_sigs(proto::BasicTypes::SignatureList::new());
+        }
+
+        // note: this cannot fail
+        self.tx.sigs.as_mut().unwrap().sigs.push(signed);
+        self.signatures.push((public, signature));
+    }
+
+    // A signature map of every signature, keyed by its full public key
+    fn signature_map(&self) -> proto::BasicTypes::SignatureMap {
+        let mut map = proto::BasicTypes::SignatureMap::new();
+
+        for (public, signature) in &self.signatures {
+            let mut pair = proto::BasicTypes::SignaturePair::new();
+            pair.set_pubKeyPrefix(public.as_bytes().to_vec());
+            pair.set_ed25519(signature.to_bytes().to_vec());
+
+            map.sigPair.push(pair);
+        }
+
+        map
+    }
+
+    // Write the body back into the transaction in the form it was read in
+    fn set_body_data(&mut self) {
+        if self.tx.has_bodyBytes() {
+            self.tx.set_bodyBytes(self.bytes.clone());
+        } else {
+            self.tx.set_body(self.body.clone());
+        }
+    }
+}
+
+/// Execute many transactions concurrently, with at most `parallelism` of them in flight at
+/// once.
+///
+/// Each transaction is signed (if not already) and submitted as by [`execute_async`], to the
+/// node it was built for; transactions are not spread over the nodes of the network. The
+/// results are returned in the same order as the transactions; one failing does not stop
+/// the others.
+///
+/// [`execute_async`]: struct.Transaction.html#method.execute_async
+pub async fn execute_all_async<T: 'static, S: 'static>(
+    transactions: &mut [Transaction<T, S>],
+    parallelism: usize,
+) -> Vec<Result<TransactionResponse, Error>> {
+    execute_stream(transactions.iter_mut().map(Transaction::<T, S>::take), parallelism)
+        .collect()
+        .await
+}
+
+/// Execute many transactions concurrently, with at most `parallelism` of them in flight at
+/// once. See [`execute_all_async`](fn.execute_all_async.html).
+pub fn execute_all<T: 'static, S: 'static>(
+    transactions: &mut [Transaction<T, S>],
+    parallelism: usize,
+) -> Vec<Result<TransactionResponse, Error>> {
+    crate::RUNTIME
+        .lock()
+        .block_on(execute_all_async(transactions, parallelism))
+}
+
+/// Execute a queue of transactions, with at most `parallelism` of them in flight at once,
+/// yielding each result in the order of the queue.
+///
+/// Unlike [`execute_all_async`], transactions are only taken from `transactions` as earlier
+/// ones finish, so a bulk job of any size (e.g. minting a large NFT drop) can be fed from a
+/// lazy iterator without building every transaction up front.
+///
+/// [`execute_all_async`]: fn.execute_all_async.html
+pub fn execute_stream<T: 'static, S: 'static>(
+    transactions: impl IntoIterator<Item = Transaction<T, S>>,
+    parallelism: usize,
+) -> impl Stream<Item = Result<TransactionResponse, Error>> {
+    stream::iter(transactions)
+        .map(|mut transaction| transaction.build().execute_async())
+        .buffered(parallelism.max(1))
+}
+
+// The path of the gRPC method a transaction is submitted to, for transports that call methods
+// by path; this must agree with the services used in `execute_async`
+fn transaction_method(body: &proto::TransactionBody::TransactionBody) -> &'static str {
+    match &body.data {
+        //////////////////////// CRYPTO TRANSACTIONS
+        Some(cryptoCreateAccount(_)) => "/proto.CryptoService/createAccount",
+        Some(cryptoUpdateAccount(_)) => "/proto.CryptoService/updateAccount",
+        Some(cryptoTransfer(_)) => "/proto.CryptoService/cryptoTransfer",
+        Some(cryptoDeleteClaim(_)) => "/proto.CryptoService/deleteClaim",
+        Some(cryptoDelete(_)) => "/proto.CryptoService/cryptoDelete",
+        //////////////////////// FILE TRANSACTIONS
+        Some(fileCreate(_)) => "/proto.FileService/createFile",
+        Some(fileAppend(_)) => "/proto.FileService/appendContent",
+        Some(fileUpdate(_)) => "/proto.FileService/updateFile",
+        Some(fileDelete(_)) => "/proto.FileService/deleteFile",
+        //////////////////////// SYSTEM TRANSACTIONS
+        Some(systemDelete(data)) if data.has_contractID() => {
+            "/proto.SmartContractService/systemDelete"
+        }
+        Some(systemDelete(_)) => "/proto.FileService/systemDelete",
+        Some(systemUndelete(data)) if data.has_contractID() => {
+            "/proto.SmartContractService/systemUndelete"
+        }
+        Some(systemUndelete(_)) => "/proto.FileService/systemUndelete",
+        //////////////////////// CONTRACT TRANSACTIONS
+        Some(contractCreateInstance(_)) => "/proto.SmartContractService/createContract",
+        Some(contractUpdateInstance(_)) => "/proto.SmartContractService/updateContract",
+        Some(contractDeleteInstance(_)) => "/proto.SmartContractService/deleteContract",
+        Some(contractCall(_)) => "/proto.SmartContractService/contractCallMethod",
+        Some(ethereumTransaction(_)) => "/proto.SmartContractService/callEthereum",
+        //////////////////////// FREEZE TRANSACTIONS
+        Some(freeze(_)) => "/proto.FreezeService/freeze",
+        //////////////////////// NODE TRANSACTIONS
+        Some(nodeCreate(_)) => "/proto.AddressBookService/createNode",
+        Some(nodeUpdate(_)) => "/proto.AddressBookService/updateNode",
+        Some(nodeDelete(_)) => "/proto.AddressBookService/deleteNode",
+        //////////////////////// UTIL TRANSACTIONS
+        Some(util_prng(_)) => "/proto.UtilService/prng",
+        Some(atomic_batch(_)) => "/proto.UtilService/atomicBatch",
+        //////////////////////// SCHEDULE TRANSACTIONS
+        Some(scheduleCreate(_)) => "/proto.ScheduleService/createSchedule",
+        Some(scheduleDelete(_)) => "/proto.ScheduleService/deleteSchedule",
+        Some(scheduleSign(_)) => "/proto.ScheduleService/signSchedule",
+
+        _ => unimplemented!(),
+    }
+}
+
+// The ed25519 signatures of a signature map, with their keys; the keys must be given in full
+fn signature_pairs(
+    map: &proto::BasicTypes::SignatureMap,
+) -> Result<Vec<(PublicKey, Signature)>, Error> {
+    use self::proto::BasicTypes::SignaturePair_oneof_signature::*;
+
+    let mut pairs = Vec::new();
+
+    for pair in &map.sigPair {
+        if let Some(ed25519(bytes)) = &pair.signature {
+            let public = PublicKey::from_bytes(pair.get_pubKeyPrefix())?;
+            pairs.push((public, Signature::from_bytes(bytes)?));
+        }
+    }
+
+    Ok(pairs)
+}
+
+impl Transaction<(), TransactionRaw> {
+    /// Deserialize a transaction serialized with `to_bytes`, or by another SDK.
+    ///
+    /// A body given as `bodyBytes` is kept and signed exactly as it was serialized. The
+    /// transaction is sent through `client`. If the operator of `client` pays for the
+    /// transaction, it is signed by the operator when it is executed.
+    pub fn from_bytes(client: &Client, bytes: &[u8]) -> Result<Self, Error> {
+        let mut tx: proto::Transaction::Transaction = protobuf::parse_from_bytes(bytes)?;
+
+        let (body, bytes) = if tx.has_bodyBytes() {
+            let bytes = tx.get_bodyBytes().to_vec();
+            let body: proto::TransactionBody::TransactionBody = protobuf::parse_from_bytes(&bytes)?;
+
+            (body, bytes)
+        } else if tx.has_body() {
+            (tx.get_body().clone(), tx.get_body().write_to_bytes()?)
+        } else {
+            Err(ErrorKind::MissingField("body"))?
+        };
+
+        if !body.has_transactionID() {
+            Err(ErrorKind::MissingField("transaction_id"))?;
+        }
+
+        let payer: AccountId = body.get_transactionID().get_accountID().clone().into();
+        let pairs = signature_pairs(&tx.take_sigMap())?;
+
+        let mut state = TransactionRaw {
+            bytes,
+            body,
+            tx,
+            signatures: Vec::new(),
+        };
+
+        // only the list of signatures is sent, so a transaction signed with nothing but a map
+        // has its signatures moved into the list
+        if state.tx.get_sigs().sigs.is_empty() {
+            for (public, signature) in pairs {
+                state.push_signature(public, signature);
+            }
+        } else {
+            state.signatures = pairs;
+        }
+
+        Ok(Self {
+            crypto_service: client.crypto.clone(),
+            file_service: client.file.clone(),
+            contract_service: client.contract.clone(),
+            schedule_service: client.schedule.clone(),
+            freeze_service: client.freeze.clone(),
+            address_book_service: client.address_book.clone(),
+            util_service: client.util.clone(),
+            secret: if client.operator == Some(payer) {
+                client.operator_secret.clone()
+            } else {
+                None
+            },
+            regenerate_id: client.regenerate_transaction_id,
+            accept_duplicate: false,
+            observer: client.observer.clone(),
+            retryable: client.retryable.clone(),
+            web: client.web.clone(),
+            kind: TransactionKind::Raw(state),
+            phantom: PhantomData,
+        })
+    }
+}
+
 impl<T: 'static, S: 'static> Transaction<T, S> {
+    // Move the transaction out, leaving it as if it had been executed
+    pub(crate) fn take(&mut self) -> Self {
+        Transaction {
+            crypto_service: self.crypto_service.clone(),
+            file_service: self.file_service.clone(),
+            contract_service: self.contract_service.clone(),
+            schedule_service: self.schedule_service.clone(),
+            freeze_service: self.freeze_service.clone(),
+            address_book_service: self.address_book_service.clone(),
+            util_service: self.util_service.clone(),
+            secret: self.secret.clone(),
+            regenerate_id: self.regenerate_id,
+            accept_duplicate: self.accept_duplicate,
+            observer: self.observer.clone(),
+            retryable: self.retryable.clone(),
+            web: self.web.clone(),
+            kind: self.kind.take(),
+            phantom: PhantomData,
+        }
+    }
+
     #[inline]
     pub(crate) fn take_raw(&mut self) -> Result<TransactionRaw, Error> {
 //        use self::proto::Transaction::Transaction_oneof_bodyData::*;
@@ -322,58 +1269,32 @@ impl<T: 'static, S: 'static> Transaction<T, S> {
             TransactionKind::Builder(_) => self.build().take_raw(),
 
             TransactionKind::Raw(mut state) => {
-                let tx = &mut state.tx;
-
                 // note: cannot fail
-                let id = tx
-                    .get_body()
-                    .transactionID
-                    .as_ref()
-                    .unwrap()
-                    .clone();
+                let operator = state.body.get_transactionID().get_accountID().clone();
 
-                let operator = id.accountID.as_ref().unwrap().clone();
-
-                if !tx.has_sigs() {
+                if !state.tx.has_sigs() {
                     // If .sign was never called this will be still need to be initialized
-                    tx.set_sigs(proto::BasicTypes::SignatureList::new());
+                    state.tx.set_sigs(proto::BasicTypes::SignatureList::new());
                 }
 
                 if let Some(secret) = &self.secret {
-                    // HACK: If an accountNum is < 1000 pretend it has a slightly more complex key structure
-                    let signature = if operator.get_accountNum() < 1000 {
-                        (&[&secret()?.sign(&state.bytes)][..]).to_proto().unwrap()
-                    } else {
-                        secret()?.sign(&state.bytes).to_proto().unwrap()
-                    };
+                    state.sign_as_operator(&secret()?);
+                }
 
-                    match &tx.get_body().clone().data {
-                        Some(cryptoTransfer(data)) => {
-                            // Insert a signature for the operator if the operator
-                            // is sending any monies
-                            for transfer in &data.transfers.as_ref().unwrap().accountAmounts {
-                                if transfer.accountID.as_ref().unwrap() == &operator {
-                                    tx.sigs.as_mut().unwrap().sigs.push(signature.clone());
-                                }
+                // a body read as `bodyBytes` is sent exactly as it was signed
+                if state.tx.has_body() {
+                    match state.body.data {
+                        Some(cryptoDelete(ref mut data)) => {
+                            if !data.has_transferAccountID() {
+                                // default the transfer account ID to the operator
+                                data.set_transferAccountID(operator);
                             }
                         }
 
                         _ => {}
                     }
 
-                    // Sign as the operator of the transaction
-                    tx.sigs.as_mut().unwrap().sigs.insert(0, signature);
-                }
-
-                match tx.mut_body().data {
-                    Some(cryptoDelete(ref mut data)) => {
-                        if !data.has_transferAccountID() {
-                            // default the transfer account ID to the operator of the transaction
-                            data.set_transferAccountID(operator);
-                        }
-                    }
-
-                    _ => {}
+                    state.set_body_data();
                 }
 
                 Ok(state)
@@ -385,6 +1306,28 @@ impl<T: 'static, S: 'static> Transaction<T, S> {
         }
     }
 
+    /// Whether to retry with a new transaction ID if the transaction expires before reaching
+    /// the node. Defaults to the setting of the client.
+    ///
+    /// The ID is only regenerated for transactions signed by nothing but the operator, as
+    /// other signatures would be of the old ID. Disable it when the transaction must be
+    /// submitted exactly once under its original ID.
+    pub fn regenerate_transaction_id(&mut self, regenerate: bool) -> &mut Self {
+        self.regenerate_id = regenerate;
+        self
+    }
+
+    /// Treat the transaction as submitted if the network has already seen its ID.
+    ///
+    /// With an ID fixed ahead of time by `transaction_id`, this allows a transaction to be
+    /// submitted again after a crash or timeout without running twice; the receipt of the ID
+    /// is then that of the original. Use it with `regenerate_transaction_id(false)`, as a
+    /// regenerated ID is never a duplicate.
+    pub fn accept_duplicate(&mut self, accept: bool) -> &mut Self {
+        self.accept_duplicate = accept;
+        self
+    }
+
     // Transition from builder to raw
     // Done before the first signature or execute
     #[inline]
@@ -408,9 +1351,15 @@ impl<T: 'static, S: 'static> Transaction<T, S> {
                 Ok(tx) => {
                     // note: this cannot fail
                     let tx: proto::Transaction::Transaction = tx;
-                    let bytes = tx.get_body().write_to_bytes().unwrap();
-
-                    self.kind = TransactionKind::Raw(TransactionRaw { tx, bytes })
+                    let body = tx.get_body().clone();
+                    let bytes = body.write_to_bytes().unwrap();
+
+                    self.kind = TransactionKind::Raw(TransactionRaw {
+                        tx,
+                        body,
+                        bytes,
+                        signatures: Vec::new(),
+                    })
                 }
 
                 Err(error) => {
@@ -453,11 +1402,16 @@ impl<T> ToProto<proto::TransactionBody::TransactionBody> for TransactionBuilder<
         let node = self.node.ok_or_else(|| ErrorKind::MissingField("node"))?;
 
         body.set_nodeAccountID(node.to_proto()?);
-        body.set_transactionValidDuration(Duration::from_secs(120).to_proto()?);
+        body.set_transactionValidDuration(self.valid_duration.to_proto()?);
         body.set_transactionFee(self.fee);
         body.set_generateRecord(self.generate_record);
         body.set_transactionID(tx_id.to_proto()?);
         body.data = Some(inner.to_proto()?);
+
+        if let Some(key) = &self.batch_key {
+            body.set_batch_key(key.to_proto()?);
+        }
+
         body.set_memo(if let Some(memo) = &self.memo {
             memo.to_owned()
         } else {
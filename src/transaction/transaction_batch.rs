@@ -0,0 +1,61 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, ErrorKind,
+};
+use failure::Error;
+use protobuf::Message;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Execute a list of inner transactions atomically; either all of them take effect or none do.
+// Each inner transaction needs a batch key, and the batch must be signed by every batch key.
+pub struct TransactionBatch {
+    transactions: Vec<Vec<u8>>,
+}
+
+interfaces!(
+    TransactionBatch: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionBatch {
+    pub fn new(client: &Client) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                transactions: Vec::new(),
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionBatch> {
+    /// Add an inner transaction, usually prepared with `batchify`. Inner transactions are
+    /// executed in the order they were added.
+    ///
+    /// This takes the inner transaction; it cannot be executed on its own afterwards.
+    pub fn inner_transaction<T: 'static, S: 'static>(
+        &mut self,
+        transaction: &mut Transaction<T, S>,
+    ) -> Result<&mut Self, Error> {
+        let state = transaction.take_raw()?;
+
+        if !state.body.has_batch_key() {
+            Err(ErrorKind::MissingField("batch_key"))?;
+        }
+
+        self.inner().transactions.push(state.tx.write_to_bytes()?);
+
+        Ok(self)
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionBatch {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::AtomicBatch::AtomicBatchTransactionBody::new();
+        data.set_transactions(self.transactions.clone().into());
+
+        Ok(TransactionBody_oneof_data::atomic_batch(data))
+    }
+}
@@ -1,4 +1,5 @@
 use crate::{
+    call_params::CallParams,
     proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
     transaction::Transaction,
     Client, ContractId,
@@ -54,6 +55,13 @@ impl Transaction<TransactionContractCall> {
         self.inner().function_parameters = params;
         self
     }
+
+    /// Which function to call, and the parameters to pass to the function, encoded from
+    /// the given `CallParams`.
+    #[inline]
+    pub fn call_params(&mut self, params: &CallParams) -> &mut Self {
+        self.function_parameters(params.assemble())
+    }
 }
 
 impl ToProto<TransactionBody_oneof_data> for TransactionContractCall {
@@ -1,7 +1,7 @@
 use crate::{
     crypto::PublicKey,
     proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
-    AccountId, FileId,
+    AccountId, ErrorKind, FileId,
 };
 
 use crate::{transaction::Transaction, Client};
@@ -11,12 +11,19 @@ use std::{any::Any, time::Duration};
 
 pub struct TransactionContractCreate {
     file_id: Option<FileId>,
+    bytecode: Option<Vec<u8>>,
     admin_key: Option<PublicKey>,
     gas: i64,
     initial_balance: i64,
     proxy_account: Option<AccountId>,
     auto_renew_period: Duration,
     constructor_parameters: Option<Vec<u8>>,
+    memo: Option<String>,
+    max_automatic_token_associations: i32,
+    auto_renew_account: Option<AccountId>,
+    staked_account: Option<AccountId>,
+    staked_node: Option<i64>,
+    decline_staking_reward: bool,
 }
 
 interfaces!(
@@ -30,24 +37,44 @@ impl TransactionContractCreate {
             client,
             Self {
                 file_id: None,
+                bytecode: None,
                 admin_key: None,
                 gas: 0,
                 initial_balance: 0,
                 proxy_account: None,
                 auto_renew_period: Duration::from_secs(7_890_000),
                 constructor_parameters: None,
+                memo: None,
+                max_automatic_token_associations: 0,
+                auto_renew_account: None,
+                staked_account: None,
+                staked_node: None,
+                decline_staking_reward: false,
             },
         )
     }
 }
 
 impl Transaction<TransactionContractCreate> {
+    /// The file containing the bytecode of the contract. Replaces any inline bytecode.
     #[inline]
     pub fn file(&mut self, id: FileId) -> &mut Self {
+        self.inner().bytecode = None;
         self.inner().file_id = Some(id);
         self
     }
 
+    /// The bytecode of the contract, sent inline with the transaction. Replaces any file.
+    ///
+    /// The bytecode must fit in a single transaction; larger contracts should be uploaded to
+    /// a file first.
+    #[inline]
+    pub fn bytecode(&mut self, bytecode: Vec<u8>) -> &mut Self {
+        self.inner().file_id = None;
+        self.inner().bytecode = Some(bytecode);
+        self
+    }
+
     #[inline]
     pub fn gas(&mut self, gas: i64) -> &mut Self {
         self.inner().gas = gas;
@@ -83,6 +110,50 @@ impl Transaction<TransactionContractCreate> {
         self.inner().constructor_parameters = Some(params);
         self
     }
+
+    /// The memo of the contract itself (max 100 bytes), as opposed to the memo of this
+    /// transaction.
+    #[inline]
+    pub fn contract_memo(&mut self, memo: impl Into<String>) -> &mut Self {
+        self.inner().memo = Some(memo.into());
+        self
+    }
+
+    /// The maximum number of tokens the contract can be implicitly associated with.
+    #[inline]
+    pub fn max_automatic_token_associations(&mut self, max: i32) -> &mut Self {
+        self.inner().max_automatic_token_associations = max;
+        self
+    }
+
+    /// The account charged to renew the contract. Defaults to the contract's own account.
+    #[inline]
+    pub fn auto_renew_account(&mut self, account: AccountId) -> &mut Self {
+        self.inner().auto_renew_account = Some(account);
+        self
+    }
+
+    /// Stake the contract to an account. Replaces any staked node.
+    #[inline]
+    pub fn staked_account(&mut self, account: AccountId) -> &mut Self {
+        self.inner().staked_node = None;
+        self.inner().staked_account = Some(account);
+        self
+    }
+
+    /// Stake the contract to a node. Replaces any staked account.
+    #[inline]
+    pub fn staked_node(&mut self, node: i64) -> &mut Self {
+        self.inner().staked_account = None;
+        self.inner().staked_node = Some(node);
+        self
+    }
+
+    #[inline]
+    pub fn decline_staking_reward(&mut self, decline: bool) -> &mut Self {
+        self.inner().decline_staking_reward = decline;
+        self
+    }
 }
 
 impl ToProto<TransactionBody_oneof_data> for TransactionContractCreate {
@@ -103,8 +174,10 @@ impl ToProto<TransactionBody_oneof_data> for TransactionContractCreate {
             data.set_proxyAccountID(account.to_proto()?);
         }
 
-        if let Some(id) = self.file_id {
-            data.set_fileID(id.to_proto()?);
+        match (self.file_id, &self.bytecode) {
+            (Some(id), _) => data.set_fileID(id.to_proto()?),
+            (None, Some(bytecode)) => data.set_initcode(bytecode.clone()),
+            (None, None) => Err(ErrorKind::MissingField("file or bytecode"))?,
         }
 
         if let Some(key) = &self.admin_key {
@@ -119,6 +192,24 @@ impl ToProto<TransactionBody_oneof_data> for TransactionContractCreate {
             data.set_constructorParameters(params.clone());
         }
 
+        if let Some(memo) = &self.memo {
+            data.set_memo(memo.clone());
+        }
+
+        data.set_max_automatic_token_associations(self.max_automatic_token_associations);
+
+        if let Some(account) = self.auto_renew_account {
+            data.set_auto_renew_account_id(account.to_proto()?);
+        }
+
+        if let Some(account) = self.staked_account {
+            data.set_staked_account_id(account.to_proto()?);
+        } else if let Some(node) = self.staked_node {
+            data.set_staked_node_id(node);
+        }
+
+        data.set_decline_reward(self.decline_staking_reward);
+
         Ok(TransactionBody_oneof_data::contractCreateInstance(data))
     }
 }
@@ -11,6 +11,7 @@ use crate::{
 pub struct TransactionContractDelete {
     id: ContractId,
     obtainer_account: Option<AccountId>,
+    obtainer_contract: Option<ContractId>,
 }
 
 interfaces!(
@@ -24,18 +25,31 @@ impl TransactionContractDelete {
             client,
             Self {
                 id,
-                obtainer_account: None
+                obtainer_account: None,
+                obtainer_contract: None,
             },
         )
     }
 }
 
 impl Transaction<TransactionContractDelete> {
+    /// Transfer the remaining hbars of the contract to an account. Replaces any
+    /// obtainer contract.
     #[inline]
     pub fn obtainer_account(&mut self, acct: AccountId) -> &mut Self {
+        self.inner().obtainer_contract = None;
         self.inner().obtainer_account = Some(acct);
         self
     }
+
+    /// Transfer the remaining hbars of the contract to another contract. Replaces any
+    /// obtainer account.
+    #[inline]
+    pub fn obtainer_contract(&mut self, contract: ContractId) -> &mut Self {
+        self.inner().obtainer_account = None;
+        self.inner().obtainer_contract = Some(contract);
+        self
+    }
 }
 
 impl ToProto<TransactionBody_oneof_data> for TransactionContractDelete {
@@ -45,6 +59,8 @@ impl ToProto<TransactionBody_oneof_data> for TransactionContractDelete {
 
         if let Some(account) = self.obtainer_account {
             data.set_transferAccountID(account.to_proto()?);
+        } else if let Some(contract) = self.obtainer_contract {
+            data.set_transferContractID(contract.to_proto()?);
         }
 
         Ok(TransactionBody_oneof_data::contractDeleteInstance(data))
@@ -7,6 +7,7 @@ use crate::{
 };
 use chrono::{DateTime, Utc};
 use failure::Error;
+use protobuf::well_known_types::{BoolValue, Int32Value, StringValue};
 use query_interface::{interfaces, vtable_for};
 use std::{any::Any, time::Duration};
 
@@ -17,6 +18,12 @@ pub struct TransactionContractUpdate {
     proxy_account: Option<AccountId>,
     auto_renew_period: Option<Duration>,
     file: Option<FileId>,
+    memo: Option<String>,
+    max_automatic_token_associations: Option<i32>,
+    auto_renew_account: Option<AccountId>,
+    staked_account: Option<AccountId>,
+    staked_node: Option<i64>,
+    decline_staking_reward: Option<bool>,
 }
 
 interfaces!(
@@ -35,6 +42,12 @@ impl TransactionContractUpdate {
                 proxy_account: None,
                 auto_renew_period: None,
                 file: None,
+                memo: None,
+                max_automatic_token_associations: None,
+                auto_renew_account: None,
+                staked_account: None,
+                staked_node: None,
+                decline_staking_reward: None,
             },
         )
     }
@@ -75,6 +88,51 @@ impl Transaction<TransactionContractUpdate> {
         self.inner().file = Some(file);
         self
     }
+
+    /// The new memo of the contract itself (max 100 bytes), as opposed to the memo of this
+    /// transaction.
+    #[inline]
+    pub fn contract_memo(&mut self, memo: impl Into<String>) -> &mut Self {
+        self.inner().memo = Some(memo.into());
+        self
+    }
+
+    #[inline]
+    pub fn max_automatic_token_associations(&mut self, max: i32) -> &mut Self {
+        self.inner().max_automatic_token_associations = Some(max);
+        self
+    }
+
+    /// Set the account charged for auto-renewal. `0:0:0` removes the auto-renew account.
+    #[inline]
+    pub fn auto_renew_account(&mut self, account: AccountId) -> &mut Self {
+        self.inner().auto_renew_account = Some(account);
+        self
+    }
+
+    /// Stake the contract to an account. Replaces any staked node; `0:0:0` removes the
+    /// staked account.
+    #[inline]
+    pub fn staked_account(&mut self, account: AccountId) -> &mut Self {
+        self.inner().staked_node = None;
+        self.inner().staked_account = Some(account);
+        self
+    }
+
+    /// Stake the contract to a node. Replaces any staked account; `-1` removes the
+    /// staked node.
+    #[inline]
+    pub fn staked_node(&mut self, node: i64) -> &mut Self {
+        self.inner().staked_account = None;
+        self.inner().staked_node = Some(node);
+        self
+    }
+
+    #[inline]
+    pub fn decline_staking_reward(&mut self, decline: bool) -> &mut Self {
+        self.inner().decline_staking_reward = Some(decline);
+        self
+    }
 }
 
 impl ToProto<TransactionBody_oneof_data> for TransactionContractUpdate {
@@ -102,6 +160,34 @@ impl ToProto<TransactionBody_oneof_data> for TransactionContractUpdate {
             data.set_fileID(file.to_proto()?);
         }
 
+        if let Some(memo) = &self.memo {
+            let mut value = StringValue::new();
+            value.set_value(memo.clone());
+            data.set_memoWrapper(value);
+        }
+
+        if let Some(max) = self.max_automatic_token_associations {
+            let mut value = Int32Value::new();
+            value.set_value(max);
+            data.set_max_automatic_token_associations(value);
+        }
+
+        if let Some(account) = self.auto_renew_account {
+            data.set_auto_renew_account_id(account.to_proto()?);
+        }
+
+        if let Some(account) = self.staked_account {
+            data.set_staked_account_id(account.to_proto()?);
+        } else if let Some(node) = self.staked_node {
+            data.set_staked_node_id(node);
+        }
+
+        if let Some(decline) = self.decline_staking_reward {
+            let mut value = BoolValue::new();
+            value.set_value(decline);
+            data.set_decline_reward(value);
+        }
+
         Ok(TransactionBody_oneof_data::contractUpdateInstance(data))
     }
 }
@@ -18,6 +18,25 @@ impl From<proto::CryptoTransfer::TransferList> for Vec<(AccountId, i64)> {
     }
 }
 
+impl ToProto<proto::CryptoTransfer::TransferList> for Vec<(AccountId, i64)> {
+    fn to_proto(&self) -> Result<proto::CryptoTransfer::TransferList, Error> {
+        let amounts: Result<Vec<proto::CryptoTransfer::AccountAmount>, Error> = self
+            .iter()
+            .map(|(id, amount)| {
+                let mut pb = proto::CryptoTransfer::AccountAmount::new();
+                pb.set_accountID(id.to_proto()?);
+                pb.set_amount(*amount);
+                Ok(pb)
+            })
+            .collect();
+
+        let mut transfers = proto::CryptoTransfer::TransferList::new();
+        transfers.set_accountAmounts(RepeatedField::from_vec(amounts?));
+
+        Ok(transfers)
+    }
+}
+
 pub struct TransactionCryptoTransfer {
     transfers: Vec<(AccountId, i64)>,
 }
@@ -48,22 +67,8 @@ impl Transaction<TransactionCryptoTransfer> {
 
 impl ToProto<TransactionBody_oneof_data> for TransactionCryptoTransfer {
     fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
-        let amounts: Result<Vec<proto::CryptoTransfer::AccountAmount>, Error> = self
-            .transfers
-            .iter()
-            .map(|(id, amount)| {
-                let mut pb = proto::CryptoTransfer::AccountAmount::new();
-                pb.set_accountID(id.to_proto()?);
-                pb.set_amount(*amount);
-                Ok(pb)
-            })
-            .collect();
-
-        let mut transfers = proto::CryptoTransfer::TransferList::new();
-        transfers.set_accountAmounts(RepeatedField::from_vec(amounts?));
-
         let mut data = proto::CryptoTransfer::CryptoTransferTransactionBody::new();
-        data.set_transfers(transfers);
+        data.set_transfers(self.transfers.to_proto()?);
 
         Ok(TransactionBody_oneof_data::cryptoTransfer(data))
     }
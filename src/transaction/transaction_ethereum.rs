@@ -0,0 +1,67 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, FileId,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Submit a raw, signed Ethereum transaction (RLP encoded type 0, 1, or 2) to be executed by
+// the network. The Ethereum signer pays for the gas; the payer of this transaction covers
+// any shortfall, up to the max gas allowance.
+pub struct TransactionEthereum {
+    ethereum_data: Vec<u8>,
+    call_data: Option<FileId>,
+    max_gas_allowance: i64,
+}
+
+interfaces!(
+    TransactionEthereum: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionEthereum {
+    pub fn new(client: &Client, ethereum_data: Vec<u8>) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                ethereum_data,
+                call_data: None,
+                max_gas_allowance: 0,
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionEthereum> {
+    /// The file holding the call data of the Ethereum transaction, for transactions too large
+    /// to send directly. The call data in the Ethereum transaction itself must then be empty.
+    #[inline]
+    pub fn call_data(&mut self, file: FileId) -> &mut Self {
+        self.inner().call_data = Some(file);
+        self
+    }
+
+    /// The maximum amount, in tinybars, that the payer of this transaction is willing to pay
+    /// for gas the Ethereum signer did not authorize.
+    #[inline]
+    pub fn max_gas_allowance(&mut self, allowance: i64) -> &mut Self {
+        self.inner().max_gas_allowance = allowance;
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionEthereum {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::EthereumTransaction::EthereumTransactionBody::new();
+        data.set_ethereum_data(self.ethereum_data.clone());
+        data.set_max_gas_allowance(self.max_gas_allowance);
+
+        if let Some(file) = &self.call_data {
+            data.set_call_data(file.to_proto()?);
+        }
+
+        Ok(TransactionBody_oneof_data::ethereumTransaction(data))
+    }
+}
@@ -1,17 +1,25 @@
-use std::any::Any;
+use std::{any::Any, mem};
 
-use failure::Error;
+use failure::{err_msg, format_err, Error};
 use query_interface::{interfaces, vtable_for};
 
 use crate::{
     proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
     transaction::Transaction,
-    Client, FileId,
+    Client, FileId, TransactionResponse,
 };
 
+// The default size of each chunk of the contents, in bytes
+const DEFAULT_CHUNK_SIZE: usize = 4096;
+
+// The default limit on how many chunks the contents may be split into
+const DEFAULT_MAX_CHUNKS: usize = 20;
+
 pub struct TransactionFileAppend {
     id: FileId,
     contents: Vec<u8>,
+    chunk_size: usize,
+    max_chunks: usize,
 }
 
 interfaces!(
@@ -22,15 +30,101 @@ interfaces!(
 impl TransactionFileAppend {
     pub fn new(client: &Client, id: FileId, contents: Vec<u8>) -> Transaction<Self> {
         Transaction::new(
-            client, 
-            Self { 
-                id, 
-                contents 
+            client,
+            Self {
+                id,
+                contents,
+                chunk_size: DEFAULT_CHUNK_SIZE,
+                max_chunks: DEFAULT_MAX_CHUNKS,
             },
         )
     }
 }
 
+impl Transaction<TransactionFileAppend> {
+    /// The most bytes of the contents `execute_chunked` appends in one transaction. Defaults
+    /// to 4096.
+    ///
+    /// A plain `execute` appends all of the contents in one transaction, whatever its size.
+    /// Smaller chunks keep each transaction well under the size limit of the network, at the
+    /// cost of more transactions and fees.
+    #[inline]
+    pub fn chunk_size(&mut self, size: usize) -> &mut Self {
+        self.inner().chunk_size = size;
+        self
+    }
+
+    /// The most chunks the contents may be split into by `execute_chunked`. Defaults to 20.
+    #[inline]
+    pub fn max_chunks(&mut self, max: usize) -> &mut Self {
+        self.inner().max_chunks = max;
+        self
+    }
+
+    /// Append the contents in chunks of `chunk_size`, one transaction per chunk.
+    ///
+    /// The chunks are submitted in order, each waiting for the receipt of the one before, so
+    /// the file is never left with its chunks out of order. Returns the response of every chunk.
+    pub async fn execute_chunked_async(
+        &mut self,
+        client: &Client,
+    ) -> Result<Vec<TransactionResponse>, Error> {
+        let (id, len, chunk_size, max_chunks) = {
+            let inner = self.inner();
+            (inner.id, inner.contents.len(), inner.chunk_size, inner.max_chunks)
+        };
+
+        if chunk_size == 0 {
+            Err(err_msg("chunk size must be greater than zero"))?;
+        }
+
+        // empty contents are still appended, as a single empty chunk
+        let count = ((len + chunk_size - 1) / chunk_size).max(1);
+
+        if count > max_chunks {
+            Err(format_err!(
+                "appending {} bytes needs {} chunks, more than the maximum of {}",
+                len,
+                count,
+                max_chunks
+            ))?;
+        }
+
+        let contents = mem::replace(&mut self.inner().contents, Vec::new());
+
+        let chunks = (0..count)
+            .map(|index| {
+                let start = index * chunk_size;
+                let end = (start + chunk_size).min(len);
+
+                TransactionFileAppend {
+                    id,
+                    contents: contents[start..end].to_vec(),
+                    chunk_size,
+                    max_chunks,
+                }
+            })
+            .collect();
+
+        let mut responses = Vec::with_capacity(count);
+
+        for mut transaction in self.take_chunks(chunks)? {
+            let response = transaction.execute_async().await?;
+            response.get_receipt_async(client).await?;
+
+            responses.push(response);
+        }
+
+        Ok(responses)
+    }
+
+    pub fn execute_chunked(&mut self, client: &Client) -> Result<Vec<TransactionResponse>, Error> {
+        crate::RUNTIME
+            .lock()
+            .block_on(self.execute_chunked_async(client))
+    }
+}
+
 impl ToProto<TransactionBody_oneof_data> for TransactionFileAppend {
     fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
         let mut data = proto::FileAppend::FileAppendTransactionBody::new();
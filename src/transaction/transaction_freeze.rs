@@ -0,0 +1,127 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, ErrorKind, FileId,
+};
+use chrono::{DateTime, Utc};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+/// The kind of network freeze or upgrade a freeze transaction performs.
+#[derive(Debug, Copy, Clone, PartialEq)]
+pub enum FreezeType {
+    /// Freeze the network at the start time, without performing an upgrade.
+    FreezeOnly,
+    /// Prepare the nodes for an upgrade using the update file, without freezing the network.
+    PrepareUpgrade,
+    /// Freeze the network at the start time and perform the previously prepared upgrade.
+    FreezeUpgrade,
+    /// Abort a pending freeze.
+    FreezeAbort,
+    /// Immediately upgrade the telemetry and metrics services using the update file.
+    TelemetryUpgrade,
+}
+
+impl From<FreezeType> for proto::FreezeType::FreezeType {
+    fn from(freeze_type: FreezeType) -> Self {
+        use self::proto::FreezeType::FreezeType::*;
+
+        match freeze_type {
+            FreezeType::FreezeOnly => FREEZE_ONLY,
+            FreezeType::PrepareUpgrade => PREPARE_UPGRADE,
+            FreezeType::FreezeUpgrade => FREEZE_UPGRADE,
+            FreezeType::FreezeAbort => FREEZE_ABORT,
+            FreezeType::TelemetryUpgrade => TELEMETRY_UPGRADE,
+        }
+    }
+}
+
+// Freeze the network for maintenance, or prepare and perform a software upgrade of the nodes.
+// Must be signed by the Hedera council.
+pub struct TransactionFreeze {
+    freeze_type: Option<FreezeType>,
+    start_time: Option<DateTime<Utc>>,
+    update_file: Option<FileId>,
+    file_hash: Vec<u8>,
+}
+
+interfaces!(
+    TransactionFreeze: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionFreeze {
+    pub fn new(client: &Client, freeze_type: FreezeType) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                freeze_type: Some(freeze_type),
+                start_time: None,
+                update_file: None,
+                file_hash: Vec::new(),
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionFreeze> {
+    /// The consensus time at which the freeze begins. Required to freeze the network.
+    #[inline]
+    pub fn start_at(&mut self, time: DateTime<Utc>) -> &mut Self {
+        self.inner().start_time = Some(time);
+        self
+    }
+
+    /// The file holding the software update. Required to prepare an upgrade.
+    #[inline]
+    pub fn update_file(&mut self, id: FileId) -> &mut Self {
+        self.inner().update_file = Some(id);
+        self
+    }
+
+    /// The SHA-384 hash of the contents of the update file, which the nodes verify before
+    /// upgrading.
+    #[inline]
+    pub fn file_hash(&mut self, hash: Vec<u8>) -> &mut Self {
+        self.inner().file_hash = hash;
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionFreeze {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let freeze_type = self
+            .freeze_type
+            .ok_or_else(|| ErrorKind::MissingField("freeze_type"))?;
+
+        let mut data = proto::Freeze::FreezeTransactionBody::new();
+        data.set_freeze_type(freeze_type.into());
+
+        match freeze_type {
+            FreezeType::FreezeOnly | FreezeType::FreezeUpgrade if self.start_time.is_none() => {
+                Err(ErrorKind::MissingField("start_time"))?
+            }
+
+            FreezeType::PrepareUpgrade | FreezeType::TelemetryUpgrade
+                if self.update_file.is_none() || self.file_hash.is_empty() =>
+            {
+                Err(ErrorKind::MissingField("update_file and file_hash"))?
+            }
+
+            _ => {}
+        }
+
+        if let Some(time) = self.start_time.as_ref() {
+            data.set_start_time(time.to_proto()?);
+        }
+
+        if let Some(file) = self.update_file {
+            data.set_update_file(file.to_proto()?);
+        }
+
+        data.set_file_hash(self.file_hash.clone());
+
+        Ok(TransactionBody_oneof_data::freeze(data))
+    }
+}
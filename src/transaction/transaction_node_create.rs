@@ -0,0 +1,182 @@
+use crate::{
+    crypto::PublicKey,
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    AccountId, Client, ErrorKind, ServiceEndpoint,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Add a new node to the network address book. Must be signed by the Hedera council and by the
+// admin key of the new node. The ID of the new node is in the receipt.
+pub struct TransactionNodeCreate {
+    account: Option<AccountId>,
+    description: String,
+    gossip_endpoints: Vec<ServiceEndpoint>,
+    service_endpoints: Vec<ServiceEndpoint>,
+    gossip_ca_certificate: Vec<u8>,
+    grpc_certificate_hash: Vec<u8>,
+    admin_key: Option<PublicKey>,
+}
+
+interfaces!(
+    TransactionNodeCreate: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionNodeCreate {
+    pub fn new(client: &Client) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                account: None,
+                description: String::new(),
+                gossip_endpoints: Vec::new(),
+                service_endpoints: Vec::new(),
+                gossip_ca_certificate: Vec::new(),
+                grpc_certificate_hash: Vec::new(),
+                admin_key: None,
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionNodeCreate> {
+    /// The account of the node, which receives its rewards.
+    #[inline]
+    pub fn account(&mut self, id: AccountId) -> &mut Self {
+        self.inner().account = Some(id);
+        self
+    }
+
+    /// A short description of the node (max 100 bytes).
+    #[inline]
+    pub fn description(&mut self, description: impl Into<String>) -> &mut Self {
+        self.inner().description = description.into();
+        self
+    }
+
+    /// Add an endpoint used for gossip between nodes. The internal endpoint goes first.
+    #[inline]
+    pub fn gossip_endpoint(&mut self, endpoint: ServiceEndpoint) -> &mut Self {
+        self.inner().gossip_endpoints.push(endpoint);
+        self
+    }
+
+    /// Add an endpoint of the gRPC services used by clients.
+    #[inline]
+    pub fn service_endpoint(&mut self, endpoint: ServiceEndpoint) -> &mut Self {
+        self.inner().service_endpoints.push(endpoint);
+        self
+    }
+
+    /// The DER encoding of the certificate the node signs gossip events with.
+    #[inline]
+    pub fn gossip_ca_certificate(&mut self, certificate: Vec<u8>) -> &mut Self {
+        self.inner().gossip_ca_certificate = certificate;
+        self
+    }
+
+    /// The SHA-384 hash of the certificate of the gRPC endpoints of the node.
+    #[inline]
+    pub fn grpc_certificate_hash(&mut self, hash: Vec<u8>) -> &mut Self {
+        self.inner().grpc_certificate_hash = hash;
+        self
+    }
+
+    /// The key that must sign updates and the deletion of the node.
+    #[inline]
+    pub fn admin_key(&mut self, key: PublicKey) -> &mut Self {
+        self.inner().admin_key = Some(key);
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionNodeCreate {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let account = self
+            .account
+            .as_ref()
+            .ok_or_else(|| ErrorKind::MissingField("account"))?;
+
+        let admin_key = self
+            .admin_key
+            .as_ref()
+            .ok_or_else(|| ErrorKind::MissingField("admin_key"))?;
+
+        let mut data = proto::NodeCreate::NodeCreateTransactionBody::new();
+        data.set_account_id(account.to_proto()?);
+        data.set_description(self.description.clone());
+        data.set_gossip_endpoint(
+            self.gossip_endpoints
+                .iter()
+                .map(ToProto::to_proto)
+                .collect::<Result<_, _>>()?,
+        );
+        data.set_service_endpoint(
+            self.service_endpoints
+                .iter()
+                .map(ToProto::to_proto)
+                .collect::<Result<_, _>>()?,
+        );
+        data.set_gossip_ca_certificate(self.gossip_ca_certificate.clone());
+        data.set_grpc_certificate_hash(self.grpc_certificate_hash.clone());
+        data.set_admin_key(admin_key.to_proto()?);
+
+        Ok(TransactionBody_oneof_data::nodeCreate(data))
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::TransactionNodeCreate;
+    use crate::{
+        proto::{ToProto, TransactionBody::TransactionBody_oneof_data},
+        AccountId, PublicKey, ServiceEndpoint,
+    };
+    use failure::Error;
+    use std::net::Ipv4Addr;
+
+    const KEY_PUBLIC_HEX: &str = "e0c8ec2758a5879ffac226a13c0c516b799e72e35141a0dd828f94d37988a4b7";
+
+    fn node_create(admin_key: Option<PublicKey>) -> TransactionNodeCreate {
+        TransactionNodeCreate {
+            account: Some(AccountId::new(0, 0, 7)),
+            description: "node 4".into(),
+            gossip_endpoints: vec![
+                ServiceEndpoint::from_ip(Ipv4Addr::new(10, 0, 0, 4), 50111),
+                ServiceEndpoint::from_ip(Ipv4Addr::new(52, 0, 0, 4), 50111),
+            ],
+            service_endpoints: vec![ServiceEndpoint::from_ip(Ipv4Addr::new(52, 0, 0, 4), 50211)],
+            gossip_ca_certificate: vec![1, 2, 3],
+            grpc_certificate_hash: vec![0xab; 48],
+            admin_key,
+        }
+    }
+
+    #[test]
+    fn test_to_proto() -> Result<(), Error> {
+        let key: PublicKey = KEY_PUBLIC_HEX.parse()?;
+
+        let data = match node_create(Some(key.clone())).to_proto()? {
+            TransactionBody_oneof_data::nodeCreate(data) => data,
+            _ => unreachable!(),
+        };
+
+        assert_eq!(data.get_account_id().get_accountNum(), 7);
+        assert_eq!(data.get_description(), "node 4");
+        assert_eq!(data.get_gossip_endpoint().len(), 2);
+        assert_eq!(data.get_gossip_endpoint()[0].get_ipAddressV4(), &[10, 0, 0, 4][..]);
+        assert_eq!(data.get_service_endpoint()[0].get_port(), 50211);
+        assert_eq!(data.get_gossip_ca_certificate(), &[1, 2, 3][..]);
+        assert_eq!(data.get_admin_key().get_ed25519(), &key.as_bytes()[..]);
+
+        Ok(())
+    }
+
+    #[test]
+    fn test_requires_admin_key() {
+        assert!(node_create(None).to_proto().is_err());
+    }
+}
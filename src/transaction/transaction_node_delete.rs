@@ -0,0 +1,52 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Remove a node from the network address book. Must be signed by the Hedera council or by the
+// admin key of the node.
+pub struct TransactionNodeDelete {
+    node_id: u64,
+}
+
+interfaces!(
+    TransactionNodeDelete: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionNodeDelete {
+    pub fn new(client: &Client, node_id: u64) -> Transaction<Self> {
+        Transaction::new(client, Self { node_id })
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionNodeDelete {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::NodeDelete::NodeDeleteTransactionBody::new();
+
+        data.set_node_id(self.node_id);
+
+        Ok(TransactionBody_oneof_data::nodeDelete(data))
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::TransactionNodeDelete;
+    use crate::proto::{ToProto, TransactionBody::TransactionBody_oneof_data};
+    use failure::Error;
+
+    #[test]
+    fn test_to_proto() -> Result<(), Error> {
+        match (TransactionNodeDelete { node_id: 4 }).to_proto()? {
+            TransactionBody_oneof_data::nodeDelete(data) => assert_eq!(data.get_node_id(), 4),
+            _ => unreachable!(),
+        }
+
+        Ok(())
+    }
+}
@@ -0,0 +1,190 @@
+use crate::{
+    crypto::PublicKey,
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    AccountId, Client, ServiceEndpoint,
+};
+use failure::Error;
+use protobuf::well_known_types::{BytesValue, StringValue};
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Update the address book entry of a node. Only what is set is changed. Must be signed by the
+// admin key of the node, and by the new account and admin key when those change.
+pub struct TransactionNodeUpdate {
+    node_id: u64,
+    account: Option<AccountId>,
+    description: Option<String>,
+    gossip_endpoints: Vec<ServiceEndpoint>,
+    service_endpoints: Vec<ServiceEndpoint>,
+    gossip_ca_certificate: Option<Vec<u8>>,
+    grpc_certificate_hash: Option<Vec<u8>>,
+    admin_key: Option<PublicKey>,
+}
+
+interfaces!(
+    TransactionNodeUpdate: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionNodeUpdate {
+    pub fn new(client: &Client, node_id: u64) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                node_id,
+                account: None,
+                description: None,
+                gossip_endpoints: Vec::new(),
+                service_endpoints: Vec::new(),
+                gossip_ca_certificate: None,
+                grpc_certificate_hash: None,
+                admin_key: None,
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionNodeUpdate> {
+    /// The new account of the node, which receives its rewards.
+    #[inline]
+    pub fn account(&mut self, id: AccountId) -> &mut Self {
+        self.inner().account = Some(id);
+        self
+    }
+
+    /// The new description of the node (max 100 bytes).
+    #[inline]
+    pub fn description(&mut self, description: impl Into<String>) -> &mut Self {
+        self.inner().description = Some(description.into());
+        self
+    }
+
+    /// Add a gossip endpoint. When any are added, they replace all the gossip endpoints of
+    /// the node.
+    #[inline]
+    pub fn gossip_endpoint(&mut self, endpoint: ServiceEndpoint) -> &mut Self {
+        self.inner().gossip_endpoints.push(endpoint);
+        self
+    }
+
+    /// Add a gRPC service endpoint. When any are added, they replace all the service endpoints
+    /// of the node.
+    #[inline]
+    pub fn service_endpoint(&mut self, endpoint: ServiceEndpoint) -> &mut Self {
+        self.inner().service_endpoints.push(endpoint);
+        self
+    }
+
+    /// The DER encoding of the new certificate the node signs gossip events with.
+    #[inline]
+    pub fn gossip_ca_certificate(&mut self, certificate: Vec<u8>) -> &mut Self {
+        self.inner().gossip_ca_certificate = Some(certificate);
+        self
+    }
+
+    /// The SHA-384 hash of the new certificate of the gRPC endpoints of the node.
+    #[inline]
+    pub fn grpc_certificate_hash(&mut self, hash: Vec<u8>) -> &mut Self {
+        self.inner().grpc_certificate_hash = Some(hash);
+        self
+    }
+
+    /// The new key that must sign updates and the deletion of the node.
+    #[inline]
+    pub fn admin_key(&mut self, key: PublicKey) -> &mut Self {
+        self.inner().admin_key = Some(key);
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionNodeUpdate {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::NodeUpdate::NodeUpdateTransactionBody::new();
+        data.set_node_id(self.node_id);
+
+        if let Some(account) = self.account.as_ref() {
+            data.set_account_id(account.to_proto()?);
+        }
+
+        if let Some(description) = &self.description {
+            let mut value = StringValue::new();
+            value.set_value(description.clone());
+            data.set_description(value);
+        }
+
+        data.set_gossip_endpoint(
+            self.gossip_endpoints
+                .iter()
+                .map(ToProto::to_proto)
+                .collect::<Result<_, _>>()?,
+        );
+        data.set_service_endpoint(
+            self.service_endpoints
+                .iter()
+                .map(ToProto::to_proto)
+                .collect::<Result<_, _>>()?,
+        );
+
+        if let Some(certificate) = &self.gossip_ca_certificate {
+            let mut value = BytesValue::new();
+            value.set_value(certificate.clone());
+            data.set_gossip_ca_certificate(value);
+        }
+
+        if let Some(hash) = &self.grpc_certificate_hash {
+            let mut value = BytesValue::new();
+            value.set_value(hash.clone());
+            data.set_grpc_certificate_hash(value);
+        }
+
+        if let Some(key) = self.admin_key.as_ref() {
+            data.set_admin_key(key.to_proto()?);
+        }
+
+        Ok(TransactionBody_oneof_data::nodeUpdate(data))
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::TransactionNodeUpdate;
+    use crate::{
+        proto::{ToProto, TransactionBody::TransactionBody_oneof_data},
+        AccountId, ServiceEndpoint,
+    };
+    use failure::Error;
+    use std::net::Ipv4Addr;
+
+    #[test]
+    fn test_to_proto() -> Result<(), Error> {
+        let update = TransactionNodeUpdate {
+            node_id: 4,
+            account: Some(AccountId::new(0, 0, 7)),
+            description: Some("node 4".into()),
+            gossip_endpoints: Vec::new(),
+            service_endpoints: vec![ServiceEndpoint::from_ip(Ipv4Addr::new(10, 0, 0, 4), 50211)],
+            gossip_ca_certificate: None,
+            grpc_certificate_hash: Some(vec![0xab; 48]),
+            admin_key: None,
+        };
+
+        let data = match update.to_proto()? {
+            TransactionBody_oneof_data::nodeUpdate(data) => data,
+            _ => unreachable!(),
+        };
+
+        assert_eq!(data.get_node_id(), 4);
+        assert_eq!(data.get_account_id().get_accountNum(), 7);
+        assert_eq!(data.get_description().get_value(), "node 4");
+        assert!(data.get_gossip_endpoint().is_empty());
+        assert_eq!(data.get_service_endpoint()[0].get_port(), 50211);
+
+        // unset fields are left out, so they are not changed
+        assert!(!data.has_gossip_ca_certificate());
+        assert_eq!(data.get_grpc_certificate_hash().get_value(), &[0xab; 48][..]);
+        assert!(!data.has_admin_key());
+
+        Ok(())
+    }
+}
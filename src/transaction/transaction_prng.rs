@@ -0,0 +1,46 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Generate a pseudorandom number, returned in the record of the transaction. Without a range
+// the record holds 384 pseudorandom bits, otherwise a number in `[0, range)`.
+pub struct TransactionPrng {
+    range: Option<i32>,
+}
+
+interfaces!(
+    TransactionPrng: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionPrng {
+    pub fn new(client: &Client) -> Transaction<Self> {
+        Transaction::new(client, Self { range: None })
+    }
+}
+
+impl Transaction<TransactionPrng> {
+    /// The exclusive upper bound of the generated number. Must be positive.
+    #[inline]
+    pub fn range(&mut self, range: i32) -> &mut Self {
+        self.inner().range = Some(range);
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionPrng {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::UtilPrng::UtilPrngTransactionBody::new();
+
+        if let Some(range) = self.range {
+            data.set_range(range);
+        }
+
+        Ok(TransactionBody_oneof_data::util_prng(data))
+    }
+}
@@ -0,0 +1,206 @@
+use crate::{
+    crypto::PublicKey,
+    proto::{
+        self, SchedulableTransactionBody::SchedulableTransactionBody_oneof_data as Schedulable,
+        ToProto, TransactionBody::TransactionBody_oneof_data,
+    },
+    transaction::Transaction,
+    AccountId, Client, ErrorKind,
+};
+use chrono::{DateTime, Utc};
+use failure::{format_err, Error};
+use query_interface::{interfaces, vtable_for, Object};
+use std::{any::Any, time::Duration};
+
+// Create a schedule that will execute the scheduled transaction once it has collected enough
+// signatures. The receipt contains the ID of the schedule and the ID to query for the receipt
+// or record of the scheduled transaction.
+pub struct TransactionScheduleCreate {
+    scheduled: Option<ScheduledTransaction>,
+    memo: Option<String>,
+    admin_key: Option<PublicKey>,
+    payer: Option<AccountId>,
+    expiration_time: Option<DateTime<Utc>>,
+    wait_for_expiry: bool,
+}
+
+// The builder state of the transaction being scheduled
+pub(crate) struct ScheduledTransaction {
+    pub(crate) inner: Box<dyn Object>,
+    pub(crate) fee: u64,
+    pub(crate) memo: Option<String>,
+}
+
+interfaces!(
+    TransactionScheduleCreate: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionScheduleCreate {
+    pub fn new(client: &Client) -> Transaction<Self> {
+        Transaction::new(client, Self::scheduling(None))
+    }
+
+    pub(crate) fn scheduling(scheduled: Option<ScheduledTransaction>) -> Self {
+        Self {
+            scheduled,
+            memo: None,
+            admin_key: None,
+            payer: None,
+            expiration_time: None,
+            wait_for_expiry: false,
+        }
+    }
+}
+
+impl Transaction<TransactionScheduleCreate> {
+    /// The transaction to schedule. Its fee and memo are kept; its transaction ID and node are
+    /// replaced by those of the schedule create.
+    #[inline]
+    pub fn scheduled_transaction<T: 'static>(&mut self, tx: &mut Transaction<T>) -> &mut Self {
+        self.inner().scheduled = Some(tx.take_scheduled());
+        self
+    }
+
+    /// The memo of the schedule itself (max 100 bytes), as opposed to the memo of this
+    /// transaction.
+    #[inline]
+    pub fn schedule_memo(&mut self, memo: impl Into<String>) -> &mut Self {
+        self.inner().memo = Some(memo.into());
+        self
+    }
+
+    /// The key that can delete the schedule before it executes.
+    #[inline]
+    pub fn admin_key(&mut self, key: PublicKey) -> &mut Self {
+        self.inner().admin_key = Some(key);
+        self
+    }
+
+    /// The account charged for the fee of the scheduled transaction when it executes. Defaults
+    /// to the payer of the schedule create.
+    #[inline]
+    pub fn payer(&mut self, account: AccountId) -> &mut Self {
+        self.inner().payer = Some(account);
+        self
+    }
+
+    /// The time at which the schedule expires if it has not collected enough signatures. This
+    /// may be far in the future, up to the maximum the network allows.
+    #[inline]
+    pub fn expires_at(&mut self, expiration: DateTime<Utc>) -> &mut Self {
+        self.inner().expiration_time = Some(expiration);
+        self
+    }
+
+    #[inline]
+    pub fn expires_in(&mut self, duration: Duration) -> &mut Self {
+        self.expires_at(Utc::now() + chrono::Duration::from_std(duration).unwrap())
+    }
+
+    /// Execute the scheduled transaction at its expiration time rather than as soon as it has
+    /// collected all the required signatures. Requires an expiration time to be set.
+    #[inline]
+    pub fn wait_for_expiry(&mut self, wait: bool) -> &mut Self {
+        self.inner().wait_for_expiry = wait;
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionScheduleCreate {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        if self.wait_for_expiry && self.expiration_time.is_none() {
+            Err(ErrorKind::MissingField("expiration_time"))?;
+        }
+
+        let scheduled = self
+            .scheduled
+            .as_ref()
+            .ok_or_else(|| ErrorKind::MissingField("scheduled_transaction"))?;
+
+        // Get a reference to the trait implementation for ToProto for the scheduled builder
+        let inner: &dyn ToProto<TransactionBody_oneof_data> = match scheduled.inner.query_ref() {
+            Some(inner) => inner,
+
+            // Not possible in safe rust to get here
+            _ => unreachable!(),
+        };
+
+        let mut body = proto::SchedulableTransactionBody::SchedulableTransactionBody::new();
+        body.set_transactionFee(scheduled.fee);
+        body.set_memo(scheduled.memo.clone().unwrap_or_default());
+        body.data = Some(schedulable(inner.to_proto()?)?);
+
+        let mut data = proto::ScheduleCreate::ScheduleCreateTransactionBody::new();
+        data.set_scheduledTransactionBody(body);
+
+        if let Some(memo) = &self.memo {
+            data.set_memo(memo.clone());
+        }
+
+        if let Some(key) = &self.admin_key {
+            data.set_adminKey(key.to_proto()?);
+        }
+
+        if let Some(payer) = self.payer {
+            data.set_payerAccountID(payer.to_proto()?);
+        }
+
+        if let Some(time) = self.expiration_time.as_ref() {
+            data.set_expiration_time(time.to_proto()?);
+        }
+
+        data.set_wait_for_expiry(self.wait_for_expiry);
+
+        Ok(TransactionBody_oneof_data::scheduleCreate(data))
+    }
+}
+
+fn schedulable(data: TransactionBody_oneof_data) -> Result<Schedulable, Error> {
+    use self::TransactionBody_oneof_data::*;
+
+    Ok(match data {
+        contractCall(data) => Schedulable::contractCall(data),
+        contractCreateInstance(data) => Schedulable::contractCreateInstance(data),
+        contractUpdateInstance(data) => Schedulable::contractUpdateInstance(data),
+        contractDeleteInstance(data) => Schedulable::contractDeleteInstance(data),
+        cryptoCreateAccount(data) => Schedulable::cryptoCreateAccount(data),
+        cryptoDelete(data) => Schedulable::cryptoDelete(data),
+        cryptoTransfer(data) => Schedulable::cryptoTransfer(data),
+        cryptoUpdateAccount(data) => Schedulable::cryptoUpdateAccount(data),
+        fileAppend(data) => Schedulable::fileAppend(data),
+        fileCreate(data) => Schedulable::fileCreate(data),
+        fileDelete(data) => Schedulable::fileDelete(data),
+        fileUpdate(data) => Schedulable::fileUpdate(data),
+        systemDelete(data) => Schedulable::systemDelete(data),
+        systemUndelete(data) => Schedulable::systemUndelete(data),
+        freeze(data) => Schedulable::freeze(data),
+        scheduleDelete(data) => Schedulable::scheduleDelete(data),
+
+        _ => Err(format_err!("transaction cannot be scheduled"))?,
+    })
+}
+
+// The reverse of `schedulable`, to read a scheduled transaction as any other transaction
+pub(crate) fn unschedulable(data: Schedulable) -> TransactionBody_oneof_data {
+    use self::TransactionBody_oneof_data::*;
+
+    match data {
+        Schedulable::contractCall(data) => contractCall(data),
+        Schedulable::contractCreateInstance(data) => contractCreateInstance(data),
+        Schedulable::contractUpdateInstance(data) => contractUpdateInstance(data),
+        Schedulable::contractDeleteInstance(data) => contractDeleteInstance(data),
+        Schedulable::cryptoCreateAccount(data) => cryptoCreateAccount(data),
+        Schedulable::cryptoDelete(data) => cryptoDelete(data),
+        Schedulable::cryptoTransfer(data) => cryptoTransfer(data),
+        Schedulable::cryptoUpdateAccount(data) => cryptoUpdateAccount(data),
+        Schedulable::fileAppend(data) => fileAppend(data),
+        Schedulable::fileCreate(data) => fileCreate(data),
+        Schedulable::fileDelete(data) => fileDelete(data),
+        Schedulable::fileUpdate(data) => fileUpdate(data),
+        Schedulable::systemDelete(data) => systemDelete(data),
+        Schedulable::systemUndelete(data) => systemUndelete(data),
+        Schedulable::freeze(data) => freeze(data),
+        Schedulable::scheduleDelete(data) => scheduleDelete(data),
+    }
+}
@@ -0,0 +1,35 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, ScheduleId,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Delete the given schedule, so that the scheduled transaction never executes. Must be signed
+// by the admin key of the schedule.
+pub struct TransactionScheduleDelete {
+    id: ScheduleId,
+}
+
+interfaces!(
+    TransactionScheduleDelete: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionScheduleDelete {
+    pub fn new(client: &Client, id: ScheduleId) -> Transaction<Self> {
+        Transaction::new(client, Self { id })
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionScheduleDelete {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::ScheduleDelete::ScheduleDeleteTransactionBody::new();
+
+        data.set_scheduleID(self.id.to_proto()?);
+
+        Ok(TransactionBody_oneof_data::scheduleDelete(data))
+    }
+}
@@ -0,0 +1,35 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, ScheduleId,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Add the signatures on this transaction to the given schedule. Once the schedule has collected
+// the signatures of all the keys required by the scheduled transaction, it is executed.
+pub struct TransactionScheduleSign {
+    id: ScheduleId,
+}
+
+interfaces!(
+    TransactionScheduleSign: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionScheduleSign {
+    pub fn new(client: &Client, id: ScheduleId) -> Transaction<Self> {
+        Transaction::new(client, Self { id })
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionScheduleSign {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::ScheduleSign::ScheduleSignTransactionBody::new();
+
+        data.set_scheduleID(self.id.to_proto()?);
+
+        Ok(TransactionBody_oneof_data::scheduleSign(data))
+    }
+}
@@ -0,0 +1,83 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, ContractId, ErrorKind, FileId,
+};
+use chrono::{DateTime, Utc};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::{any::Any, time::Duration};
+
+// Delete a file or smart contract as a Hedera administrator. The entity is marked as deleted
+// and can be restored with a system undelete until the given expiration time.
+pub struct TransactionSystemDelete {
+    file: Option<FileId>,
+    contract: Option<ContractId>,
+    expiration_time: Option<DateTime<Utc>>,
+}
+
+interfaces!(
+    TransactionSystemDelete: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionSystemDelete {
+    pub fn new(client: &Client) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                file: None,
+                contract: None,
+                expiration_time: None,
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionSystemDelete> {
+    /// The file to delete. Replaces any contract set previously.
+    #[inline]
+    pub fn file(&mut self, id: FileId) -> &mut Self {
+        self.inner().contract = None;
+        self.inner().file = Some(id);
+        self
+    }
+
+    /// The contract to delete. Replaces any file set previously.
+    #[inline]
+    pub fn contract(&mut self, id: ContractId) -> &mut Self {
+        self.inner().file = None;
+        self.inner().contract = Some(id);
+        self
+    }
+
+    /// The time at which the deleted entity should be permanently removed.
+    #[inline]
+    pub fn expires_at(&mut self, expiration: DateTime<Utc>) -> &mut Self {
+        self.inner().expiration_time = Some(expiration);
+        self
+    }
+
+    #[inline]
+    pub fn expires_in(&mut self, duration: Duration) -> &mut Self {
+        self.expires_at(Utc::now() + chrono::Duration::from_std(duration).unwrap())
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionSystemDelete {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::SystemDelete::SystemDeleteTransactionBody::new();
+
+        match (self.file, self.contract) {
+            (Some(file), _) => data.set_fileID(file.to_proto()?),
+            (None, Some(contract)) => data.set_contractID(contract.to_proto()?),
+            (None, None) => Err(ErrorKind::MissingField("file or contract"))?,
+        }
+
+        if let Some(expiration_time) = self.expiration_time.as_ref() {
+            data.set_expirationTime(expiration_time.to_proto()?);
+        }
+
+        Ok(TransactionBody_oneof_data::systemDelete(data))
+    }
+}
@@ -0,0 +1,64 @@
+use crate::{
+    proto::{self, ToProto, TransactionBody::TransactionBody_oneof_data},
+    transaction::Transaction,
+    Client, ContractId, ErrorKind, FileId,
+};
+use failure::Error;
+use query_interface::{interfaces, vtable_for};
+use std::any::Any;
+
+// Restore a file or smart contract that was removed with a system delete, as long as
+// it has not yet expired.
+pub struct TransactionSystemUndelete {
+    file: Option<FileId>,
+    contract: Option<ContractId>,
+}
+
+interfaces!(
+    TransactionSystemUndelete: dyn Any,
+    dyn ToProto<TransactionBody_oneof_data>
+);
+
+impl TransactionSystemUndelete {
+    pub fn new(client: &Client) -> Transaction<Self> {
+        Transaction::new(
+            client,
+            Self {
+                file: None,
+                contract: None,
+            },
+        )
+    }
+}
+
+impl Transaction<TransactionSystemUndelete> {
+    /// The file to undelete. Replaces any contract set previously.
+    #[inline]
+    pub fn file(&mut self, id: FileId) -> &mut Self {
+        self.inner().contract = None;
+        self.inner().file = Some(id);
+        self
+    }
+
+    /// The contract to undelete. Replaces any file set previously.
+    #[inline]
+    pub fn contract(&mut self, id: ContractId) -> &mut Self {
+        self.inner().file = None;
+        self.inner().contract = Some(id);
+        self
+    }
+}
+
+impl ToProto<TransactionBody_oneof_data> for TransactionSystemUndelete {
+    fn to_proto(&self) -> Result<TransactionBody_oneof_data, Error> {
+        let mut data = proto::SystemUndelete::SystemUndeleteTransactionBody::new();
+
+        match (self.file, self.contract) {
+            (Some(file), _) => data.set_fileID(file.to_proto()?),
+            (None, Some(contract)) => data.set_contractID(contract.to_proto()?),
+            (None, None) => Err(ErrorKind::MissingField("file or contract"))?,
+        }
+
+        Ok(TransactionBody_oneof_data::systemUndelete(data))
+    }
+}
@@ -13,6 +13,12 @@ use crate::{
 pub struct TransactionId {
     pub account_id: AccountId,
     pub transaction_valid_start: DateTime<Utc>,
+    /// Set for the ID of a scheduled transaction, which shares the account and valid start of
+    /// the schedule create that scheduled it.
+    pub scheduled: bool,
+    /// Set for the ID of a child transaction, which shares the account and valid start of the
+    /// transaction that caused it; the first child has a nonce of `1`.
+    pub nonce: i32,
 }
 
 impl TransactionId {
@@ -22,6 +28,38 @@ impl TransactionId {
             // Allows the transaction to be accepted as long as the
             // server is not more than 10 seconds behind us
             transaction_valid_start: Utc::now() - Duration::seconds(10),
+            scheduled: false,
+            nonce: 0,
+        }
+    }
+
+    /// An ID with a valid start chosen by the caller, such as one persisted before submitting
+    /// so the transaction can be submitted again under the same ID.
+    pub fn with_valid_start(account_id: AccountId, transaction_valid_start: DateTime<Utc>) -> Self {
+        Self {
+            account_id,
+            transaction_valid_start,
+            scheduled: false,
+            nonce: 0,
+        }
+    }
+
+    /// The ID of the transaction scheduled by the schedule create with this ID.
+    pub fn scheduled(&self) -> Self {
+        Self {
+            scheduled: true,
+            ..self.clone()
+        }
+    }
+
+    /// The ID of a child transaction caused by the transaction with this ID.
+    ///
+    /// The children are numbered from `1`, in the order of the `children` of the receipt
+    /// or record of the parent when queried with `include_children`.
+    pub fn child(&self, nonce: i32) -> Self {
+        Self {
+            nonce,
+            ..self.clone()
         }
     }
 }
@@ -34,7 +72,17 @@ impl fmt::Display for TransactionId {
             self.account_id,
             self.transaction_valid_start.timestamp(),
             self.transaction_valid_start.timestamp_subsec_nanos()
-        )
+        )?;
+
+        if self.scheduled {
+            write!(f, "?scheduled")?;
+        }
+
+        if self.nonce != 0 {
+            write!(f, "/{}", self.nonce)?;
+        }
+
+        Ok(())
     }
 }
 
@@ -44,10 +92,22 @@ impl FromStr for TransactionId {
     fn from_str(s: &str) -> Result<Self, Self::Err> {
         use crate::timestamp::Timestamp;
 
+        let (s, nonce) = match s.rfind('/') {
+            Some(index) if s.contains('@') => (&s[..index], s[index + 1..].parse()?),
+            _ => (s, 0),
+        };
+
+        let (s, scheduled) = match s.find("?scheduled") {
+            Some(index) if index + "?scheduled".len() == s.len() => (&s[..index], true),
+            _ => (s, false),
+        };
+
         if let Some((account_id, timestamp)) = s.split('@').next_tuple() {
             Ok(Self {
                 account_id: account_id.parse()?,
                 transaction_valid_start: Timestamp::from_str(timestamp)?.into(),
+                scheduled,
+                nonce,
             })
         } else {
             let b = hex::decode(s)?;
@@ -59,6 +119,8 @@ impl FromStr for TransactionId {
             Ok(Self {
                 account_id: pb.take_accountID().into(),
                 transaction_valid_start: pb.take_transactionValidStart().into(),
+                scheduled: pb.get_scheduled(),
+                nonce: pb.get_nonce(),
             })
         }
     }
@@ -72,6 +134,8 @@ impl From<proto::BasicTypes::TransactionID> for TransactionId {
         Self {
             transaction_valid_start,
             account_id,
+            scheduled: pb.get_scheduled(),
+            nonce: pb.get_nonce(),
         }
     }
 }
@@ -81,6 +145,8 @@ impl ToProto<proto::BasicTypes::TransactionID> for TransactionId {
         let mut id = proto::BasicTypes::TransactionID::new();
         id.set_transactionValidStart(self.transaction_valid_start.to_proto()?);
         id.set_accountID(self.account_id.to_proto()?);
+        id.set_scheduled(self.scheduled);
+        id.set_nonce(self.nonce);
 
         Ok(id)
     }
@@ -99,9 +165,19 @@ mod tests {
         let transaction_id = TransactionId {
             account_id,
             transaction_valid_start,
+            scheduled: false,
+            nonce: 0,
         };
 
         assert_eq!(format!("{}", transaction_id), "7:5:1001@1234567.10001");
+
+        let scheduled = TransactionId {
+            scheduled: true,
+            ..transaction_id
+        };
+
+        assert_eq!(format!("{}", scheduled), "7:5:1001@1234567.10001?scheduled");
+        assert_eq!(format!("{}", transaction_id.child(2)), "7:5:1001@1234567.10001/2");
     }
 
     #[test]
@@ -111,6 +187,8 @@ mod tests {
         let transaction_id = TransactionId {
             account_id,
             transaction_valid_start,
+            scheduled: false,
+            nonce: 0,
         };
 
         assert_eq!(
@@ -118,6 +196,17 @@ mod tests {
             transaction_id
         );
 
+        assert!(
+            "7:5:1001@1234567.10001?scheduled"
+                .parse::<TransactionId>()?
+                .scheduled
+        );
+
+        assert_eq!(
+            "7:5:1001@1234567.10001/2".parse::<TransactionId>()?,
+            transaction_id.child(2)
+        );
+
         Ok(())
     }
 
@@ -128,6 +217,8 @@ mod tests {
         let transaction_id = TransactionId {
             account_id,
             transaction_valid_start,
+            scheduled: false,
+            nonce: 0,
         };
 
         assert_eq!(
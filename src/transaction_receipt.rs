@@ -1,4 +1,10 @@
-use crate::{proto, AccountId, ContractId, FileId, Status};
+use crate::{
+    proto::{self, ToProto},
+    AccountId, ContractId, ExchangeRates, FileId, ScheduleId, Status, TokenId, TopicId,
+    TransactionId,
+};
+use failure::Error;
+use protobuf::{Message, RepeatedField};
 
 #[repr(C)]
 #[derive(Debug, Clone)]
@@ -7,6 +13,100 @@ pub struct TransactionReceipt {
     pub account_id: Option<Box<AccountId>>,
     pub contract_id: Option<Box<ContractId>>,
     pub file_id: Option<Box<FileId>>,
+    pub topic_id: Option<Box<TopicId>>,
+    pub token_id: Option<Box<TokenId>>,
+    pub schedule_id: Option<Box<ScheduleId>>,
+    /// The ID to query for the receipt or record of the transaction that was scheduled.
+    ///
+    /// For a schedule sign this is only set when the signature caused the scheduled transaction
+    /// to execute. A schedule that waits for expiry executes at its expiration time, so its
+    /// receipt is only available from then, using `ScheduleInfo::scheduled_transaction_id`.
+    pub scheduled_transaction_id: Option<Box<TransactionId>>,
+    /// The ID of the new node, in the receipt of a node create.
+    pub node_id: u64,
+    /// The exchange rates in effect when the transaction reached consensus.
+    pub exchange_rate: Option<ExchangeRates>,
+    /// The sequence number of the topic after a message was submitted to it.
+    pub topic_sequence_number: u64,
+    /// The running hash of the topic after a message was submitted to it.
+    pub topic_running_hash: Vec<u8>,
+    pub topic_running_hash_version: u64,
+    /// The total supply of the token after a mint or burn.
+    pub total_supply: u64,
+    /// The serial numbers of the NFTs created by a mint.
+    pub serial_numbers: Vec<i64>,
+    /// The receipts of the other transactions submitted with the same ID, when requested.
+    pub duplicates: Vec<TransactionReceipt>,
+    /// The receipts of the child transactions this transaction caused, when requested.
+    ///
+    /// The child at index `i` has the ID `transaction_id.child(i + 1)`.
+    pub children: Vec<TransactionReceipt>,
+}
+
+impl TransactionReceipt {
+    /// A receipt with only `status` set, e.g. to program a `MockNetwork`.
+    pub fn with_status(status: Status) -> Self {
+        Self {
+            status,
+            account_id: None,
+            contract_id: None,
+            file_id: None,
+            topic_id: None,
+            token_id: None,
+            schedule_id: None,
+            scheduled_transaction_id: None,
+            node_id: 0,
+            exchange_rate: None,
+            topic_sequence_number: 0,
+            topic_running_hash: Vec::new(),
+            topic_running_hash_version: 0,
+            total_supply: 0,
+            serial_numbers: Vec::new(),
+            duplicates: Vec::new(),
+            children: Vec::new(),
+        }
+    }
+
+    /// The IDs of the `children` of this receipt, in order, given the ID of the transaction
+    /// it is the receipt of.
+    pub fn child_transaction_ids(&self, transaction_id: &TransactionId) -> Vec<TransactionId> {
+        (1..=self.children.len() as i32).map(|nonce| transaction_id.child(nonce)).collect()
+    }
+
+    /// Serialize this receipt, including its duplicates and children, so it can be stored or
+    /// passed to another service without querying the network again.
+    ///
+    /// Duplicates and children are not part of a receipt on the wire, so the receipt is written
+    /// as a node answers a query for it, with them alongside.
+    pub fn to_bytes(&self) -> Result<Vec<u8>, Error> {
+        Ok(self.to_response()?.write_to_bytes()?)
+    }
+
+    /// Read a receipt serialized with `to_bytes`.
+    pub fn from_bytes(bytes: &[u8]) -> Result<Self, Error> {
+        let response: proto::TransactionGetReceipt::TransactionGetReceiptResponse =
+            protobuf::parse_from_bytes(bytes)?;
+
+        Ok(response.into())
+    }
+
+    // The receipt as a node answers a query for it
+    pub(crate) fn to_response(
+        &self,
+    ) -> Result<proto::TransactionGetReceipt::TransactionGetReceiptResponse, Error> {
+        let mut response = proto::TransactionGetReceipt::TransactionGetReceiptResponse::new();
+        response.set_receipt(self.to_proto()?);
+
+        let duplicates: Result<Vec<_>, Error> =
+            self.duplicates.iter().map(ToProto::to_proto).collect();
+        response.set_duplicateTransactionReceipts(RepeatedField::from_vec(duplicates?));
+
+        let children: Result<Vec<_>, Error> =
+            self.children.iter().map(ToProto::to_proto).collect();
+        response.set_child_transaction_receipts(RepeatedField::from_vec(children?));
+
+        Ok(response)
+    }
 }
 
 impl std::fmt::Display for TransactionReceipt {
@@ -36,11 +136,159 @@ impl From<proto::TransactionReceipt::TransactionReceipt> for TransactionReceipt
             None
         };
 
+        let topic_id = if receipt.has_topicID() {
+            Some(Box::new(receipt.take_topicID().into()))
+        } else {
+            None
+        };
+
+        let token_id = if receipt.has_tokenID() {
+            Some(Box::new(receipt.take_tokenID().into()))
+        } else {
+            None
+        };
+
+        let schedule_id = if receipt.has_scheduleID() {
+            Some(Box::new(receipt.take_scheduleID().into()))
+        } else {
+            None
+        };
+
+        let exchange_rate = if receipt.has_exchangeRate() {
+            Some(receipt.take_exchangeRate().into())
+        } else {
+            None
+        };
+
+        let scheduled_transaction_id = if receipt.has_scheduledTransactionID() {
+            Some(Box::new(receipt.take_scheduledTransactionID().into()))
+        } else {
+            None
+        };
+
         Self {
             status: receipt.get_status().into(),
             account_id,
             contract_id,
             file_id,
+            topic_id,
+            token_id,
+            schedule_id,
+            scheduled_transaction_id,
+            node_id: receipt.get_node_id(),
+            exchange_rate,
+            topic_sequence_number: receipt.get_topicSequenceNumber(),
+            topic_running_hash: receipt.take_topicRunningHash(),
+            topic_running_hash_version: receipt.get_topicRunningHashVersion(),
+            total_supply: receipt.get_newTotalSupply(),
+            serial_numbers: receipt.take_serialNumbers(),
+            duplicates: Vec::new(),
+            children: Vec::new(),
         }
     }
 }
+
+impl From<proto::TransactionGetReceipt::TransactionGetReceiptResponse> for TransactionReceipt {
+    fn from(mut response: proto::TransactionGetReceipt::TransactionGetReceiptResponse) -> Self {
+        let mut receipt: TransactionReceipt = response.take_receipt().into();
+
+        receipt.duplicates = response
+            .take_duplicateTransactionReceipts()
+            .into_iter()
+            .map(Into::into)
+            .collect();
+
+        receipt.children = response
+            .take_child_transaction_receipts()
+            .into_iter()
+            .map(Into::into)
+            .collect();
+
+        receipt
+    }
+}
+
+impl ToProto<proto::TransactionReceipt::TransactionReceipt> for TransactionReceipt {
+    fn to_proto(&self) -> Result<proto::TransactionReceipt::TransactionReceipt, Error> {
+        let mut receipt = proto::TransactionReceipt::TransactionReceipt::new();
+        receipt.set_status(self.status.into());
+
+        if let Some(id) = &self.account_id {
+            receipt.set_accountID(id.to_proto()?);
+        }
+
+        if let Some(id) = &self.file_id {
+            receipt.set_fileID(id.to_proto()?);
+        }
+
+        if let Some(id) = &self.contract_id {
+            receipt.set_contractID(id.to_proto()?);
+        }
+
+        if let Some(id) = &self.topic_id {
+            receipt.set_topicID(id.to_proto()?);
+        }
+
+        if let Some(id) = &self.token_id {
+            receipt.set_tokenID(id.to_proto()?);
+        }
+
+        if let Some(id) = &self.schedule_id {
+            receipt.set_scheduleID(id.to_proto()?);
+        }
+
+        if let Some(id) = &self.scheduled_transaction_id {
+            receipt.set_scheduledTransactionID(id.to_proto()?);
+        }
+
+        if let Some(rates) = &self.exchange_rate {
+            receipt.set_exchangeRate(rates.to_proto()?);
+        }
+
+        receipt.set_node_id(self.node_id);
+        receipt.set_topicSequenceNumber(self.topic_sequence_number);
+        receipt.set_topicRunningHash(self.topic_running_hash.clone());
+        receipt.set_topicRunningHashVersion(self.topic_running_hash_version);
+        receipt.set_newTotalSupply(self.total_supply);
+        receipt.set_serialNumbers(self.serial_numbers.clone());
+
+        Ok(receipt)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::TransactionReceipt;
+    use crate::{
+        proto::{self, ToProto},
+        AccountId, Status,
+    };
+    use failure::Error;
+    use protobuf::Message;
+
+    #[test]
+    fn test_bytes() -> Result<(), Error> {
+        let mut child = proto::TransactionReceipt::TransactionReceipt::new();
+        child.set_status(Status::Success.into());
+        child.set_accountID(AccountId::new(0, 0, 1001).to_proto()?);
+
+        let mut response = proto::TransactionGetReceipt::TransactionGetReceiptResponse::new();
+        response.mut_receipt().set_status(Status::Success.into());
+        response.mut_receipt().set_serialNumbers(vec![1, 2]);
+        response.mut_child_transaction_receipts().push(child);
+
+        let receipt = TransactionReceipt::from_bytes(&response.write_to_bytes()?)?;
+
+        assert_eq!(receipt.status, Status::Success);
+        assert_eq!(receipt.serial_numbers, vec![1, 2]);
+        assert_eq!(receipt.children.len(), 1);
+        assert_eq!(receipt.children[0].account_id, Some(Box::new(AccountId::new(0, 0, 1001))));
+
+        let receipt = TransactionReceipt::from_bytes(&receipt.to_bytes()?)?;
+
+        assert_eq!(receipt.serial_numbers, vec![1, 2]);
+        assert_eq!(receipt.children[0].account_id, Some(Box::new(AccountId::new(0, 0, 1001))));
+
+        Ok(())
+    }
+}
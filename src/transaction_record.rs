@@ -1,6 +1,12 @@
-use crate::{id::AccountId, proto, function_result::ContractFunctionResult, TransactionReceipt};
+use crate::{
+    function_result::ContractFunctionResult,
+    id::{AccountId, PendingAirdropId, ScheduleId, TokenId},
+    proto::{self, ToProto},
+    TransactionId, TransactionReceipt,
+};
 use chrono::{DateTime, Utc};
 use failure::{err_msg, Error};
+use protobuf::{well_known_types::UInt32Value, Message, RepeatedField};
 use try_from::{TryFrom, TryInto};
 
 #[derive(Debug, Clone)]
@@ -10,20 +16,331 @@ pub enum TransactionRecordBody {
     Transfer(Vec<(AccountId, i64)>),
 }
 
+/// A token association that was created implicitly while handling a transaction.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub struct TokenAssociation {
+    pub token_id: TokenId,
+    pub account_id: AccountId,
+}
+
+/// A transfer of hbars, or of a fungible token, into (positive) or out of (negative) one
+/// account.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub struct Transfer {
+    pub account_id: AccountId,
+    pub amount: i64,
+    /// Whether the transfer was paid out of an allowance approved by the account.
+    pub is_approval: bool,
+}
+
+/// The transfers of one token made as a result of a transaction.
+#[derive(Debug, Clone, PartialEq)]
+pub struct TokenTransferList {
+    pub token_id: TokenId,
+    /// The transfers of a fungible token, in its lowest denomination.
+    pub transfers: Vec<Transfer>,
+    /// The transfers of the NFTs of a non-fungible token.
+    pub nft_transfers: Vec<NftTransfer>,
+    /// The decimals the token was expected to have, if the transfer checked them.
+    pub expected_decimals: Option<u32>,
+}
+
+/// The transfer of one NFT from one account to another.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub struct NftTransfer {
+    pub sender: AccountId,
+    pub receiver: AccountId,
+    pub serial_number: i64,
+    /// Whether the transfer was paid out of an allowance approved by the sender.
+    pub is_approval: bool,
+}
+
+/// A custom fee of a token that was charged for transferring it.
+#[derive(Debug, Clone, PartialEq)]
+pub struct AssessedCustomFee {
+    pub amount: i64,
+    /// The token the fee was paid in, or `None` for hbar.
+    pub token_id: Option<TokenId>,
+    pub fee_collector: AccountId,
+    /// The accounts that paid the fee.
+    pub payers: Vec<AccountId>,
+}
+
+/// An airdrop left pending by a transaction, as its receiver was not associated with the token.
+#[derive(Debug, Clone, Copy, PartialEq)]
+pub struct PendingAirdropRecord {
+    /// The ID to claim or cancel the airdrop with.
+    pub id: PendingAirdropId,
+    /// The amount of a fungible airdrop; `0` for an NFT.
+    pub amount: u64,
+}
+
 #[derive(Debug, Clone)]
 pub struct TransactionRecord {
     pub receipt: TransactionReceipt,
     pub transaction_hash: Vec<u8>,
     pub consensus_timestamp: Option<DateTime<Utc>>,
+    pub transaction_id: Option<TransactionId>,
     pub memo: String,
     pub transaction_fee: u64,
     pub body: TransactionRecordBody,
+    /// All hbar transfers made as a result of this transaction, including fees.
+    pub transfers: Vec<Transfer>,
+    /// All token transfers made as a result of this transaction.
+    pub token_transfers: Vec<TokenTransferList>,
+    /// The custom fees charged for the token transfers of this transaction.
+    pub assessed_custom_fees: Vec<AssessedCustomFee>,
+    /// The staking rewards paid to accounts as a result of this transaction.
+    pub paid_staking_rewards: Vec<(AccountId, i64)>,
+    /// The schedule that executed this transaction, if it was scheduled.
+    pub schedule_ref: Option<ScheduleId>,
+    pub automatic_token_associations: Vec<TokenAssociation>,
+    /// In the record of a child transaction, the consensus timestamp of its parent.
+    pub parent_consensus_timestamp: Option<DateTime<Utc>>,
+    /// The records of the other transactions submitted with the same ID, when requested.
+    pub duplicates: Vec<TransactionRecord>,
+    /// The records of the child transactions this transaction caused, when requested.
+    ///
+    /// The child at index `i` has the ID `transaction_id.child(i + 1)`.
+    pub children: Vec<TransactionRecord>,
+    /// The 384 pseudorandom bits generated by a PRNG transaction without a range.
+    pub prng_bytes: Option<Vec<u8>>,
+    /// The pseudorandom number generated by a PRNG transaction with a range.
+    pub prng_number: Option<i32>,
+    /// The alias of the account created by this transaction, if it has one.
+    pub alias: Option<Vec<u8>>,
+    /// The EVM address of the account created by this transaction, if it has one.
+    pub evm_address: Option<Vec<u8>>,
+    /// The airdrops this transaction left pending for their receivers to claim.
+    pub new_pending_airdrops: Vec<PendingAirdropRecord>,
+}
+
+impl TransactionRecord {
+    /// The result of the contract function or constructor this transaction executed, if any.
+    pub fn contract_function_result(&self) -> Option<&ContractFunctionResult> {
+        match &self.body {
+            TransactionRecordBody::ContractCall(result)
+            | TransactionRecordBody::ContractCreate(result) => Some(result),
+
+            _ => None,
+        }
+    }
+
+    /// Serialize this record, including its duplicates and children, so it can be stored or
+    /// passed to another service without querying the network again.
+    pub fn to_bytes(&self) -> Result<Vec<u8>, Error> {
+        Ok(self.to_response()?.write_to_bytes()?)
+    }
+
+    /// Read a record serialized with `to_bytes`.
+    pub fn from_bytes(bytes: &[u8]) -> Result<Self, Error> {
+        let response: proto::TransactionGetRecord::TransactionGetRecordResponse =
+            protobuf::parse_from_bytes(bytes)?;
+
+        response.try_into()
+    }
+
+    // The record as a node answers a query for it
+    pub(crate) fn to_response(
+        &self,
+    ) -> Result<proto::TransactionGetRecord::TransactionGetRecordResponse, Error> {
+        let mut response = proto::TransactionGetRecord::TransactionGetRecordResponse::new();
+        response.set_transactionRecord(self.to_proto()?);
+
+        let duplicates: Result<Vec<_>, Error> =
+            self.duplicates.iter().map(ToProto::to_proto).collect();
+        response.set_duplicateTransactionRecords(RepeatedField::from_vec(duplicates?));
+
+        let children: Result<Vec<_>, Error> =
+            self.children.iter().map(ToProto::to_proto).collect();
+        response.set_child_transaction_records(RepeatedField::from_vec(children?));
+
+        Ok(response)
+    }
+}
+
+impl From<proto::BasicTypes::TokenAssociation> for TokenAssociation {
+    fn from(mut association: proto::BasicTypes::TokenAssociation) -> Self {
+        Self {
+            token_id: association.take_token_id().into(),
+            account_id: association.take_account_id().into(),
+        }
+    }
+}
+
+impl ToProto<proto::BasicTypes::TokenAssociation> for TokenAssociation {
+    fn to_proto(&self) -> Result<proto::BasicTypes::TokenAssociation, Error> {
+        let mut association = proto::BasicTypes::TokenAssociation::new();
+        association.set_token_id(self.token_id.to_proto()?);
+        association.set_account_id(self.account_id.to_proto()?);
+
+        Ok(association)
+    }
+}
+
+impl From<proto::CryptoTransfer::AccountAmount> for Transfer {
+    fn from(mut amount: proto::CryptoTransfer::AccountAmount) -> Self {
+        Self {
+            account_id: amount.take_accountID().into(),
+            amount: amount.get_amount(),
+            is_approval: amount.get_is_approval(),
+        }
+    }
+}
+
+impl ToProto<proto::CryptoTransfer::AccountAmount> for Transfer {
+    fn to_proto(&self) -> Result<proto::CryptoTransfer::AccountAmount, Error> {
+        let mut amount = proto::CryptoTransfer::AccountAmount::new();
+        amount.set_accountID(self.account_id.to_proto()?);
+        amount.set_amount(self.amount);
+        amount.set_is_approval(self.is_approval);
+
+        Ok(amount)
+    }
+}
+
+impl From<proto::CryptoTransfer::NftTransfer> for NftTransfer {
+    fn from(mut transfer: proto::CryptoTransfer::NftTransfer) -> Self {
+        Self {
+            sender: transfer.take_senderAccountID().into(),
+            receiver: transfer.take_receiverAccountID().into(),
+            serial_number: transfer.get_serialNumber(),
+            is_approval: transfer.get_is_approval(),
+        }
+    }
+}
+
+impl ToProto<proto::CryptoTransfer::NftTransfer> for NftTransfer {
+    fn to_proto(&self) -> Result<proto::CryptoTransfer::NftTransfer, Error> {
+        let mut transfer = proto::CryptoTransfer::NftTransfer::new();
+        transfer.set_senderAccountID(self.sender.to_proto()?);
+        transfer.set_receiverAccountID(self.receiver.to_proto()?);
+        transfer.set_serialNumber(self.serial_number);
+        transfer.set_is_approval(self.is_approval);
+
+        Ok(transfer)
+    }
+}
+
+impl From<proto::CryptoTransfer::TokenTransferList> for TokenTransferList {
+    fn from(mut list: proto::CryptoTransfer::TokenTransferList) -> Self {
+        Self {
+            token_id: list.take_token().into(),
+            transfers: list.take_transfers().into_iter().map(Into::into).collect(),
+            nft_transfers: list.take_nftTransfers().into_iter().map(Into::into).collect(),
+            expected_decimals: if list.has_expected_decimals() {
+                Some(list.get_expected_decimals().get_value())
+            } else {
+                None
+            },
+        }
+    }
+}
+
+impl ToProto<proto::CryptoTransfer::TokenTransferList> for TokenTransferList {
+    fn to_proto(&self) -> Result<proto::CryptoTransfer::TokenTransferList, Error> {
+        let mut list = proto::CryptoTransfer::TokenTransferList::new();
+        list.set_token(self.token_id.to_proto()?);
+
+        let transfers: Result<Vec<_>, Error> =
+            self.transfers.iter().map(ToProto::to_proto).collect();
+        list.set_transfers(RepeatedField::from_vec(transfers?));
+
+        let nft_transfers: Result<Vec<_>, Error> =
+            self.nft_transfers.iter().map(ToProto::to_proto).collect();
+        list.set_nftTransfers(RepeatedField::from_vec(nft_transfers?));
+
+        if let Some(decimals) = self.expected_decimals {
+            let mut value = UInt32Value::new();
+            value.set_value(decimals);
+            list.set_expected_decimals(value);
+        }
+
+        Ok(list)
+    }
+}
+
+impl From<proto::CustomFees::AssessedCustomFee> for AssessedCustomFee {
+    fn from(mut fee: proto::CustomFees::AssessedCustomFee) -> Self {
+        Self {
+            amount: fee.get_amount(),
+            token_id: if fee.has_token_id() {
+                Some(fee.take_token_id().into())
+            } else {
+                None
+            },
+            fee_collector: fee.take_fee_collector_account_id().into(),
+            payers: fee
+                .take_effective_payer_account_id()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+        }
+    }
+}
+
+impl ToProto<proto::CustomFees::AssessedCustomFee> for AssessedCustomFee {
+    fn to_proto(&self) -> Result<proto::CustomFees::AssessedCustomFee, Error> {
+        let mut fee = proto::CustomFees::AssessedCustomFee::new();
+        fee.set_amount(self.amount);
+
+        if let Some(id) = &self.token_id {
+            fee.set_token_id(id.to_proto()?);
+        }
+
+        fee.set_fee_collector_account_id(self.fee_collector.to_proto()?);
+
+        let payers: Result<Vec<_>, Error> = self.payers.iter().map(ToProto::to_proto).collect();
+        fee.set_effective_payer_account_id(RepeatedField::from_vec(payers?));
+
+        Ok(fee)
+    }
+}
+
+impl TryFrom<proto::TransactionRecord::PendingAirdropRecord> for PendingAirdropRecord {
+    type Err = Error;
+
+    fn try_from(mut record: proto::TransactionRecord::PendingAirdropRecord) -> Result<Self, Error> {
+        Ok(Self {
+            id: record.take_pending_airdrop_id().try_into()?,
+            amount: record.get_pending_airdrop_value().get_amount(),
+        })
+    }
+}
+
+impl ToProto<proto::TransactionRecord::PendingAirdropRecord> for PendingAirdropRecord {
+    fn to_proto(&self) -> Result<proto::TransactionRecord::PendingAirdropRecord, Error> {
+        let mut record = proto::TransactionRecord::PendingAirdropRecord::new();
+        record.set_pending_airdrop_id(self.id.to_proto()?);
+
+        if self.amount != 0 {
+            record.mut_pending_airdrop_value().set_amount(self.amount);
+        }
+
+        Ok(record)
+    }
 }
 
 impl TryFrom<proto::TransactionRecord::TransactionRecord> for TransactionRecord {
     type Err = Error;
 
     fn try_from(mut record: proto::TransactionRecord::TransactionRecord) -> Result<Self, Error> {
+        use self::proto::TransactionRecord::TransactionRecord_oneof_entropy as Entropy;
+
+        let (prng_bytes, prng_number) = match record.entropy.take() {
+            Some(Entropy::prng_bytes(bytes)) => (Some(bytes), None),
+            Some(Entropy::prng_number(number)) => (None, Some(number)),
+            None => (None, None),
+        };
+
+        let transfers = record
+            .get_transferList()
+            .get_accountAmounts()
+            .iter()
+            .cloned()
+            .map(Into::into)
+            .collect();
+
         Ok(Self {
             receipt: record.take_receipt().into(),
             transaction_hash: record.take_transactionHash(),
@@ -32,6 +349,11 @@ impl TryFrom<proto::TransactionRecord::TransactionRecord> for TransactionRecord
             } else {
                 None
             },
+            transaction_id: if record.has_transactionID() {
+                Some(record.take_transactionID().into())
+            } else {
+                None
+            },
             memo: record.take_memo(),
             transaction_fee: record.get_transactionFee(),
             body: {
@@ -45,10 +367,161 @@ impl TryFrom<proto::TransactionRecord::TransactionRecord> for TransactionRecord
                     Err(err_msg("transaction record contained no body"))?
                 }
             },
+            transfers,
+            schedule_ref: if record.has_scheduleRef() {
+                Some(record.take_scheduleRef().into())
+            } else {
+                None
+            },
+            automatic_token_associations: record
+                .take_automatic_token_associations()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            parent_consensus_timestamp: if record.has_parent_consensus_timestamp() {
+                Some(record.take_parent_consensus_timestamp().into())
+            } else {
+                None
+            },
+            token_transfers: record
+                .take_tokenTransferLists()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            assessed_custom_fees: record
+                .take_assessed_custom_fees()
+                .into_iter()
+                .map(Into::into)
+                .collect(),
+            paid_staking_rewards: record
+                .take_paid_staking_rewards()
+                .into_iter()
+                .map(|mut a| (a.take_accountID().into(), a.get_amount()))
+                .collect(),
+            prng_bytes,
+            prng_number,
+            alias: Some(record.take_alias()).filter(|alias| !alias.is_empty()),
+            evm_address: Some(record.take_evm_address()).filter(|address| !address.is_empty()),
+            new_pending_airdrops: record
+                .take_new_pending_airdrops()
+                .into_iter()
+                .map(TryInto::try_into)
+                .collect::<Result<_, _>>()?,
+            duplicates: Vec::new(),
+            children: Vec::new(),
         })
     }
 }
 
+impl TryFrom<proto::TransactionGetRecord::TransactionGetRecordResponse> for TransactionRecord {
+    type Err = Error;
+
+    fn try_from(
+        mut response: proto::TransactionGetRecord::TransactionGetRecordResponse,
+    ) -> Result<Self, Error> {
+        let mut record: TransactionRecord = response.take_transactionRecord().try_into()?;
+
+        record.duplicates = response
+            .take_duplicateTransactionRecords()
+            .into_iter()
+            .map(TryInto::try_into)
+            .collect::<Result<_, _>>()?;
+
+        record.children = response
+            .take_child_transaction_records()
+            .into_iter()
+            .map(TryInto::try_into)
+            .collect::<Result<_, _>>()?;
+
+        Ok(record)
+    }
+}
+
+impl ToProto<proto::TransactionRecord::TransactionRecord> for TransactionRecord {
+    fn to_proto(&self) -> Result<proto::TransactionRecord::TransactionRecord, Error> {
+        let mut record = proto::TransactionRecord::TransactionRecord::new();
+        record.set_receipt(self.receipt.to_proto()?);
+        record.set_transactionHash(self.transaction_hash.clone());
+
+        if let Some(timestamp) = &self.consensus_timestamp {
+            record.set_consensusTimestamp(timestamp.to_proto()?);
+        }
+
+        if let Some(id) = &self.transaction_id {
+            record.set_transactionID(id.to_proto()?);
+        }
+
+        record.set_memo(self.memo.clone());
+        record.set_transactionFee(self.transaction_fee);
+
+        match &self.body {
+            TransactionRecordBody::ContractCall(result) => {
+                record.set_contractCallResult(result.to_proto()?)
+            }
+
+            TransactionRecordBody::ContractCreate(result) => {
+                record.set_contractCreateResult(result.to_proto()?)
+            }
+
+            // the transfers of the body are the transfer list of the record
+            TransactionRecordBody::Transfer(_) => {}
+        }
+
+        let transfers: Result<Vec<_>, Error> =
+            self.transfers.iter().map(ToProto::to_proto).collect();
+        record.mut_transferList().set_accountAmounts(RepeatedField::from_vec(transfers?));
+
+        if let Some(id) = &self.schedule_ref {
+            record.set_scheduleRef(id.to_proto()?);
+        }
+
+        let associations: Result<Vec<_>, Error> = self
+            .automatic_token_associations
+            .iter()
+            .map(ToProto::to_proto)
+            .collect();
+        record.set_automatic_token_associations(RepeatedField::from_vec(associations?));
+
+        if let Some(timestamp) = &self.parent_consensus_timestamp {
+            record.set_parent_consensus_timestamp(timestamp.to_proto()?);
+        }
+
+        let token_transfers: Result<Vec<_>, Error> =
+            self.token_transfers.iter().map(ToProto::to_proto).collect();
+        record.set_tokenTransferLists(RepeatedField::from_vec(token_transfers?));
+
+        let fees: Result<Vec<_>, Error> =
+            self.assessed_custom_fees.iter().map(ToProto::to_proto).collect();
+        record.set_assessed_custom_fees(RepeatedField::from_vec(fees?));
+
+        let mut rewards: proto::CryptoTransfer::TransferList =
+            self.paid_staking_rewards.to_proto()?;
+        record.set_paid_staking_rewards(rewards.take_accountAmounts());
+
+        if let Some(alias) = &self.alias {
+            record.set_alias(alias.clone());
+        }
+
+        if let Some(address) = &self.evm_address {
+            record.set_evm_address(address.clone());
+        }
+
+        let airdrops: Result<Vec<_>, Error> =
+            self.new_pending_airdrops.iter().map(ToProto::to_proto).collect();
+        record.set_new_pending_airdrops(RepeatedField::from_vec(airdrops?));
+
+        if let Some(bytes) = &self.prng_bytes {
+            record.set_prng_bytes(bytes.clone());
+        }
+
+        if let Some(number) = self.prng_number {
+            record.set_prng_number(number);
+        }
+
+        Ok(record)
+    }
+}
+
 impl TryFrom<proto::ContractGetRecords::ContractGetRecordsResponse> for Vec<TransactionRecord> {
     type Err = Error;
 
@@ -62,3 +535,25 @@ impl TryFrom<proto::ContractGetRecords::ContractGetRecordsResponse> for Vec<Tran
             .collect::<Result<Self, _>>()
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use super::Transfer;
+    use crate::proto::ToProto;
+    use failure::Error;
+
+    #[test]
+    fn test_transfer_keeps_approval() -> Result<(), Error> {
+        let transfer = Transfer {
+            account_id: "0:0:1001".parse()?,
+            amount: -10,
+            is_approval: true,
+        };
+
+        let amount: crate::proto::CryptoTransfer::AccountAmount = transfer.to_proto()?;
+        assert!(amount.get_is_approval());
+        assert_eq!(Transfer::from(amount), transfer);
+
+        Ok(())
+    }
+}
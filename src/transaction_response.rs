@@ -0,0 +1,112 @@
+use crate::{
+    AccountId, Client, ErrorKind, Status, TransactionId, TransactionReceipt, TransactionRecord,
+};
+use failure::{format_err, Error};
+use std::time::{Duration, Instant};
+
+// How many times to ask the mirror node for a transaction, which it only has a few seconds
+// after consensus
+const MIRROR_ATTEMPTS: u32 = 5;
+
+/// The response to a transaction that passed pre-check.
+///
+/// Passing pre-check only means the node accepted the transaction; use `get_receipt`
+/// to wait for it to reach consensus.
+#[derive(Debug, Clone)]
+pub struct TransactionResponse {
+    pub transaction_id: TransactionId,
+    /// The node the transaction was sent to.
+    pub node_id: AccountId,
+    /// The SHA-384 hash of the signed transaction.
+    pub transaction_hash: Vec<u8>,
+}
+
+impl TransactionResponse {
+    /// Wait for the transaction to reach consensus, failing with `ErrorKind::ReceiptStatus`
+    /// if it was not successful.
+    ///
+    /// If the consensus node cannot give the receipt and `Client::set_mirror_receipt_fallback`
+    /// is set, the outcome is taken from the mirror node instead.
+    pub async fn get_receipt_async(&self, client: &Client) -> Result<TransactionReceipt, Error> {
+        let receipt = match client
+            .transaction(self.transaction_id.clone())
+            .receipt()
+            .wait_async()
+            .await
+        {
+            Ok(receipt) => receipt,
+
+            Err(error) => {
+                if !client.mirror_receipt_fallback || client.mirror.is_none() {
+                    return Err(error);
+                }
+
+                log::debug!(
+                    target: "hedera::transaction",
+                    "failed to get receipt of {}; asking the mirror node: {}",
+                    self.transaction_id,
+                    error
+                );
+
+                // report the error of the consensus node if the mirror node cannot help
+                self.mirror_receipt(client).await.map_err(|_| error)?
+            }
+        };
+
+        match receipt.status {
+            Status::Success => Ok(receipt),
+            status => Err(ErrorKind::ReceiptStatus {
+                status,
+                transaction_id: self.transaction_id.clone(),
+            })?,
+        }
+    }
+
+    async fn mirror_receipt(&self, client: &Client) -> Result<TransactionReceipt, Error> {
+        let mirror = client.mirror()?;
+        let id = &self.transaction_id;
+
+        for attempt in 1..=MIRROR_ATTEMPTS {
+            // the first is the one that was not a duplicate
+            let transaction = mirror
+                .transaction_async(id)
+                .await
+                .ok()
+                .and_then(|transactions| {
+                    transactions
+                        .into_iter()
+                        .find(|tx| tx.scheduled == id.scheduled && tx.nonce == id.nonce)
+                });
+
+            if let Some(transaction) = transaction {
+                return transaction.receipt();
+            }
+
+            if attempt < MIRROR_ATTEMPTS {
+                tokio::timer::delay(Instant::now() + Duration::from_secs(2)).await;
+            }
+        }
+
+        Err(format_err!("mirror node has no transaction {}", id))
+    }
+
+    pub fn get_receipt(&self, client: &Client) -> Result<TransactionReceipt, Error> {
+        crate::RUNTIME.lock().block_on(self.get_receipt_async(client))
+    }
+
+    /// Wait for the transaction to reach consensus, then get its record. Fails with
+    /// `ErrorKind::ReceiptStatus` if the transaction was not successful.
+    pub async fn get_record_async(&self, client: &Client) -> Result<TransactionRecord, Error> {
+        self.get_receipt_async(client).await?;
+
+        client
+            .transaction(self.transaction_id.clone())
+            .record()
+            .get_async()
+            .await
+    }
+
+    pub fn get_record(&self, client: &Client) -> Result<TransactionRecord, Error> {
+        crate::RUNTIME.lock().block_on(self.get_record_async(client))
+    }
+}
@@ -0,0 +1,87 @@
+use crate::proto;
+use std::fmt;
+
+/// A semantic version of a software component of the network.
+#[derive(Debug, Clone, Default, PartialEq)]
+pub struct SemanticVersion {
+    pub major: i32,
+    pub minor: i32,
+    pub patch: i32,
+    pub pre: String,
+    pub build: String,
+}
+
+impl fmt::Display for SemanticVersion {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        write!(f, "{}.{}.{}", self.major, self.minor, self.patch)?;
+
+        if !self.pre.is_empty() {
+            write!(f, "-{}", self.pre)?;
+        }
+
+        if !self.build.is_empty() {
+            write!(f, "+{}", self.build)?;
+        }
+
+        Ok(())
+    }
+}
+
+impl From<proto::BasicTypes::SemanticVersion> for SemanticVersion {
+    fn from(mut version: proto::BasicTypes::SemanticVersion) -> Self {
+        Self {
+            major: version.get_major(),
+            minor: version.get_minor(),
+            patch: version.get_patch(),
+            pre: version.take_pre(),
+            build: version.take_build(),
+        }
+    }
+}
+
+/// The version of this crate.
+pub const VERSION: &str = env!("CARGO_PKG_VERSION");
+
+/// The versions of the software deployed on a node.
+///
+/// This crate does not record which HAPI release the definitions in `proto/` were taken from,
+/// so it has no supported HAPI version to compare `hapi_proto_version` with; check it against
+/// the release an application was tested with instead.
+#[derive(Debug, Clone, PartialEq)]
+pub struct NetworkVersionInfo {
+    /// The version of the protobuf API (HAPI) the node understands.
+    pub hapi_proto_version: SemanticVersion,
+    /// The version of Hedera Services running on the node.
+    pub hedera_services_version: SemanticVersion,
+}
+
+impl From<proto::NetworkGetVersionInfo::NetworkGetVersionInfoResponse> for NetworkVersionInfo {
+    fn from(mut response: proto::NetworkGetVersionInfo::NetworkGetVersionInfoResponse) -> Self {
+        Self {
+            hapi_proto_version: response.take_hapiProtoVersion().into(),
+            hedera_services_version: response.take_hederaServicesVersion().into(),
+        }
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::SemanticVersion;
+
+    #[test]
+    fn test_display() {
+        let mut version = SemanticVersion {
+            major: 0,
+            minor: 38,
+            patch: 10,
+            ..SemanticVersion::default()
+        };
+
+        assert_eq!(version.to_string(), "0.38.10");
+
+        version.pre = "alpha.1".into();
+        version.build = "5ff3a".into();
+
+        assert_eq!(version.to_string(), "0.38.10-alpha.1+5ff3a");
+    }
+}